@@ -23,17 +23,167 @@ import (
 
 // ComponentSpec defines the common configuration for Free5GC components
 type ComponentSpec struct {
-	// Image is the container image to use for the component
-	Image string `json:"image"`
+	// Image is the container image to use for the component, overriding the
+	// image of the free5gc version
+	// +optional
+	Image string `json:"image,omitempty"`
 	// Replicas is the number of replicas to deploy
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 	// Resources specifies the compute resources for the component
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
-	// Config is the component-specific configuration
+	// Config is the component-specific configuration. Each entry is a configuration
+	// file, keyed by file name, mounted into the configuration directory of the component.
+	// A file named after a rendered configuration file is merged with it: the file
+	// overrides the operator defaults and the typed configuration overrides the file.
 	// +optional
 	Config map[string]string `json:"config,omitempty"`
+	// Networks are the reference points of the networks attached to the component,
+	// overriding the default of the network function: N2 for the AMF, N4 for the SMF,
+	// N3, N4, N6 and N9 for the UPF, and N2, N3 and NWu for the N3IWF.
+	// Reference points without network in the spec are not attached.
+	// +kubebuilder:validation:items:Enum=N2;N3;N4;N6;N9;NWu
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+	// Ports overrides the ports of the component by name: sbi for the control plane
+	// network functions, ngap for the AMF, pfcp for the SMF and the UPF, gtpu for the UPF,
+	// ike and nat-t for the N3IWF, and http for the WebUI. The sbi and ngap ports are
+	// rendered into the configuration, unless it sets them; the other ports are fixed by
+	// free5gc and only change the port of the Service.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Ports []PortSpec `json:"ports,omitempty"`
+	// Expose exposes the Service of the component outside the cluster, such as the AMF
+	// to the gNBs. ULCL instances inherit the exposure of the UPF.
+	// +optional
+	Expose *ExposeSpec `json:"expose,omitempty"`
+	// Probes overrides the thresholds of the liveness, readiness and startup probes of
+	// the component. ULCL instances inherit the probes of the UPF.
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+	// ConfigFrom lists configuration files taken from ConfigMaps and Secrets in the
+	// namespace of the Free5GC, mounted into the configuration directory of the component.
+	// Files from ConfigMaps behave as Config entries, while files from Secrets are mounted
+	// as is and cannot be named after a rendered configuration file.
+	// Pods are rolled out when the referenced files change.
+	// +optional
+	ConfigFrom []ConfigFileSource `json:"configFrom,omitempty"`
+}
+
+// PortSpec overrides a port of a component
+type PortSpec struct {
+	// Name of the port
+	// +kubebuilder:validation:Enum=sbi;ngap;pfcp;gtpu;ike;nat-t;http
+	Name string `json:"name"`
+	// Port number
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// ExposeSpec defines how the Service of a component is exposed outside the cluster
+type ExposeSpec struct {
+	// Type of the Service
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// LoadBalancerIP is the address requested from the load balancer of a LoadBalancer
+	// Service
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+	// Annotations are added to the Service, such as the settings of the load balancer
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ExternalTrafficPolicy of a NodePort or LoadBalancer Service. Local keeps the source
+	// address of the clients, such as the gNBs, and only routes to the local pods.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	// +optional
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+	// Ingress routes the HTTP traffic of a host to the WebUI
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+}
+
+// ProbesSpec overrides the probes of a component. The control plane network functions
+// are probed on their SBI port, the UPF on its PFCP port, the N3IWF on its IKE port, the
+// WebUI on its HTTP port and MongoDB with a ping command.
+type ProbesSpec struct {
+	// Liveness overrides the probe restarting the container when it fails
+	// +optional
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+	// Readiness overrides the probe removing the pod from the Service endpoints when it
+	// fails, and holding back the network functions depending on the component
+	// +optional
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+	// Startup overrides the probe delaying the other probes until the component started
+	// +optional
+	Startup *ProbeSpec `json:"startup,omitempty"`
+	// SBIPath is the path of the SBI probed with HTTP GET requests, over HTTPS when the
+	// SBI scheme is https. Without it, the probes only open a TCP connection to the SBI.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	SBIPath string `json:"sbiPath,omitempty"`
+}
+
+// ProbeSpec overrides the thresholds of a probe, the unset ones keeping their defaults
+type ProbeSpec struct {
+	// Disabled removes the probe
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// InitialDelaySeconds is the delay before the first probe
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// PeriodSeconds is the interval between the probes
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// TimeoutSeconds is the time after which a probe fails
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the probe fails
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// SuccessThreshold is the number of consecutive successes after which the probe
+	// succeeds again. It must be 1 for the liveness and startup probes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+}
+
+// IngressSpec defines the Ingress of the WebUI
+type IngressSpec struct {
+	// Host is the host name the WebUI is served on
+	Host string `json:"host"`
+	// ClassName is the IngressClass of the Ingress, defaulting to the default class of
+	// the cluster
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+	// TLSSecretName is the Secret holding the TLS certificate of the host. Without it the
+	// WebUI is served over plain HTTP.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Annotations are added to the Ingress, such as the settings of the Ingress controller
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ConfigFileSource selects a configuration file from a ConfigMap or Secret key.
+// Exactly one of ConfigMapKeyRef and SecretKeyRef must be set.
+type ConfigFileSource struct {
+	// Name of the configuration file, defaults to the selected key
+	// +optional
+	Name string `json:"name,omitempty"`
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 // NetworkSpec defines the network configuration for Free5GC components
@@ -53,38 +203,62 @@ type NetworkSpec struct {
 	// N9Network is the network configuration for N9 interface (F1-U)
 	// +optional
 	N9Network *NetworkAttachmentConfig `json:"n9Network,omitempty"`
+	// NWuNetwork is the network configuration for NWu interface (UE to N3IWF)
+	// +optional
+	NWuNetwork *NetworkAttachmentConfig `json:"nwuNetwork,omitempty"`
+	// IPFamilies are the IP families of the core, the first one being the primary
+	// family: IPv4 (the default), IPv6, or both for dual-stack. They set the IP
+	// families of the Services and the wildcard addresses the network functions bind to.
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=IPv4;IPv6
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
-// NetworkAttachmentConfig defines the configuration for a network attachment
+// NetworkAttachmentConfig defines the configuration for a network attachment.
+// When Type is set, the operator creates the NetworkAttachmentDefinition of the
+// network. Otherwise Name refers to a NetworkAttachmentDefinition managed outside
+// the operator.
 type NetworkAttachmentConfig struct {
 	// Name is the name of the NetworkAttachmentDefinition
 	Name string `json:"name"`
-	// Interface is the name of the interface in the pod
-	Interface string `json:"interface"`
-	// Type is the type of network (ipvlan, macvlan)
+	// Interface is the name of the interface in the pod, defaults to the
+	// lowercase name of the reference point, such as n3
+	// +optional
+	Interface string `json:"interface,omitempty"`
+	// Type is the CNI plugin of the network
+	// +kubebuilder:validation:Enum=macvlan;ipvlan
 	// +optional
 	Type string `json:"type,omitempty"`
-	// Mode is the mode for ipvlan (l2, l3)
+	// Mode is the mode of the CNI plugin: bridge (default), private, vepa or passthru
+	// for macvlan, and l2 (default), l3 or l3s for ipvlan
 	// +optional
 	Mode string `json:"mode,omitempty"`
-	// Master is the master interface for the network
+	// Master is the host interface the network is attached to, defaults to the
+	// interface of the default route of the node
 	// +optional
 	MasterInterface string `json:"masterInterface,omitempty"`
-	// Subnet is the subnet IP address
+	// Subnet is the subnet IP address.
+	// Deprecated: set CIDR instead.
 	// +optional
 	Subnet string `json:"subnet,omitempty"`
-	// CIDR is the network CIDR
+	// CIDR is the network CIDR, from which the addresses of the pods are allocated.
+	// Without CIDR, pods only get the addresses set for them.
 	// +optional
 	CIDR string `json:"cidr,omitempty"`
-	// Gateway is the gateway IP address
+	// Gateway is the gateway IP address, never allocated to pods
 	// +optional
 	Gateway string `json:"gateway,omitempty"`
-	// ExcludeIP is the IP to exclude from allocation
+	// ExcludeIP is an IP address or CIDR excluded from allocation
 	// +optional
 	ExcludeIP string `json:"excludeIP,omitempty"`
-	// StaticIP is the static IP address to assign
+	// StaticIP is the static IP address to assign, unless the network function
+	// attached to the network is configured with an address on it
 	// +optional
 	StaticIP string `json:"staticIP,omitempty"`
+	// MAC is the MAC address of the pod interface
+	// +optional
+	MAC string `json:"mac,omitempty"`
 }
 
 // SBIConfig defines the Service Based Interface configuration
@@ -95,18 +269,497 @@ type SBIConfig struct {
 	RegisterIPv4 string `json:"registerIPv4,omitempty"`
 	// BindingIPv4 address
 	BindingIPv4 string `json:"bindingIPv4,omitempty"`
+	// RegisterIP is the IPv4 or IPv6 address registered at the NRF, preferred over RegisterIPv4
+	// +optional
+	RegisterIP string `json:"registerIP,omitempty"`
+	// BindingIP is the IPv4 or IPv6 address the SBI server binds to, preferred over BindingIPv4
+	// +optional
+	BindingIP string `json:"bindingIP,omitempty"`
 	// Port number
 	Port int32 `json:"port,omitempty"`
 }
 
 // SNSSAIConfig defines the Single Network Slice Selection Assistance Information
 type SNSSAIConfig struct {
-	// Slice/Service Type
+	// Slice/Service Type, from 1 to 255
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
 	SST int32 `json:"sst,omitempty"`
-	// Slice Differentiator
+	// Slice Differentiator, as 6 hexadecimal digits
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{6}$`
 	SD string `json:"sd,omitempty"`
 }
 
+// PLMNID defines a Public Land Mobile Network identifier
+type PLMNID struct {
+	// Mobile Country Code
+	MCC string `json:"mcc"`
+	// Mobile Network Code
+	MNC string `json:"mnc"`
+}
+
+// TAIConfig defines a Tracking Area Identity
+type TAIConfig struct {
+	// PLMN ID
+	PLMNID PLMNID `json:"plmnId"`
+	// Tracking Area Code
+	TAC string `json:"tac"`
+}
+
+// GUAMIConfig defines a Globally Unique AMF Identifier
+type GUAMIConfig struct {
+	// PLMN ID
+	PLMNID PLMNID `json:"plmnId"`
+	// AMF ID (AMF Region ID, AMF Set ID and AMF Pointer as hex)
+	AMFID string `json:"amfId"`
+}
+
+// PLMNSupportConfig defines the slices supported in a PLMN
+type PLMNSupportConfig struct {
+	// PLMN ID
+	PLMNID PLMNID `json:"plmnId"`
+	// SNSSAI List
+	SNSSAIList []SNSSAIConfig `json:"snssaiList,omitempty"`
+}
+
+// SecurityConfig defines the NAS security algorithm order
+type SecurityConfig struct {
+	// Integrity algorithms by order of preference (NIA0-NIA3)
+	// +optional
+	IntegrityOrder []string `json:"integrityOrder,omitempty"`
+	// Ciphering algorithms by order of preference (NEA0-NEA3)
+	// +optional
+	CipheringOrder []string `json:"cipheringOrder,omitempty"`
+}
+
+// NASTimerConfig defines a NAS retransmission timer
+type NASTimerConfig struct {
+	// Enable the timer
+	Enable bool `json:"enable"`
+	// Expire time (e.g. 6s)
+	// +optional
+	ExpireTime string `json:"expireTime,omitempty"`
+	// Maximum number of retries
+	// +optional
+	MaxRetryTimes int32 `json:"maxRetryTimes,omitempty"`
+}
+
+// NASTimersConfig defines the NAS timers of the AMF
+type NASTimersConfig struct {
+	// T3502 value in seconds
+	// +optional
+	T3502Value int32 `json:"t3502Value,omitempty"`
+	// T3512 value in seconds
+	// +optional
+	T3512Value int32 `json:"t3512Value,omitempty"`
+	// Non-3GPP deregistration timer value in seconds
+	// +optional
+	Non3gppDeregistrationTimerValue int32 `json:"non3gppDeregistrationTimerValue,omitempty"`
+	// T3513 (paging) timer
+	// +optional
+	T3513 *NASTimerConfig `json:"t3513,omitempty"`
+	// T3522 (deregistration request) timer
+	// +optional
+	T3522 *NASTimerConfig `json:"t3522,omitempty"`
+	// T3550 (registration accept) timer
+	// +optional
+	T3550 *NASTimerConfig `json:"t3550,omitempty"`
+	// T3560 (authentication/security mode) timer
+	// +optional
+	T3560 *NASTimerConfig `json:"t3560,omitempty"`
+	// T3565 (notification) timer
+	// +optional
+	T3565 *NASTimerConfig `json:"t3565,omitempty"`
+	// T3570 (identity request) timer
+	// +optional
+	T3570 *NASTimerConfig `json:"t3570,omitempty"`
+}
+
+// NetworkNameConfig defines the network name sent to UEs
+type NetworkNameConfig struct {
+	// Full network name
+	// +optional
+	Full string `json:"full,omitempty"`
+	// Short network name
+	// +optional
+	Short string `json:"short,omitempty"`
+}
+
+// PLMNSpec defines the identity of the PLMN served by the core
+type PLMNSpec struct {
+	// PLMN IDs served by the core. The first one is the home PLMN.
+	// +kubebuilder:validation:MinItems=1
+	PLMNIDs []PLMNID `json:"plmnIds"`
+	// Tracking areas supported by the core
+	// +optional
+	TAIs []TAIConfig `json:"tais,omitempty"`
+	// Network name sent to UEs
+	// +optional
+	NetworkName *NetworkNameConfig `json:"networkName,omitempty"`
+}
+
+// SliceSpec defines a network slice of the core
+type SliceSpec struct {
+	// S-NSSAI of the slice
+	SNSSAIConfig `json:",inline"`
+	// Data networks reachable in the slice
+	// +optional
+	DNNs []SliceDNNSpec `json:"dnns,omitempty"`
+	// Names of the ULCL instances serving the slice, every UPF when empty.
+	// The slice is always served by the SMF of the core.
+	// +optional
+	UPFs []string `json:"upfs,omitempty"`
+}
+
+// SliceDNNSpec defines a data network reachable in a slice
+type SliceDNNSpec struct {
+	// Data Network Name
+	DNN string `json:"dnn"`
+	// DNS servers handed out to UEs
+	// +optional
+	DNS *DNSConfig `json:"dns,omitempty"`
+	// UE IPv4 pool CIDR of the data network
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// UE IPv6 pool CIDR of the data network, along with CIDR for dual-stack UEs
+	// +optional
+	IPv6CIDR string `json:"ipv6Cidr,omitempty"`
+}
+
+// AMFConfig defines the AMF-specific configuration
+type AMFConfig struct {
+	// AMF name
+	// +optional
+	AMFName string `json:"amfName,omitempty"`
+	// Network name
+	// +optional
+	NetworkName *NetworkNameConfig `json:"networkName,omitempty"`
+	// NGAP IP List
+	// +optional
+	NGAPIPList []string `json:"ngapIpList,omitempty"`
+	// NGAP port
+	// +optional
+	NGAPPort int32 `json:"ngapPort,omitempty"`
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// Service Name List
+	// +optional
+	ServiceNameList []string `json:"serviceNameList,omitempty"`
+	// Served GUAMI List
+	// +optional
+	ServedGUAMIList []GUAMIConfig `json:"servedGuamiList,omitempty"`
+	// Supported TAI List
+	// +optional
+	SupportTAIList []TAIConfig `json:"supportTaiList,omitempty"`
+	// PLMN Support List
+	// +optional
+	PLMNSupportList []PLMNSupportConfig `json:"plmnSupportList,omitempty"`
+	// Supported DNN List
+	// +optional
+	SupportDNNList []string `json:"supportDnnList,omitempty"`
+	// NRF URI
+	// +optional
+	NRFURI string `json:"nrfUri,omitempty"`
+	// Security algorithm order
+	// +optional
+	Security *SecurityConfig `json:"security,omitempty"`
+	// NAS timers
+	// +optional
+	NASTimers *NASTimersConfig `json:"nasTimers,omitempty"`
+}
+
+// AMFSpec defines the configuration for AMF
+type AMFSpec struct {
+	ComponentSpec `json:",inline"`
+	// AMFConfig contains AMF-specific configuration, rendered into amfcfg.yaml
+	// +optional
+	AMFConfig *AMFConfig `json:"amfConfig,omitempty"`
+}
+
+// DNSConfig defines the DNS servers handed out to UEs
+type DNSConfig struct {
+	// IPv4 DNS server
+	// +optional
+	IPv4 string `json:"ipv4,omitempty"`
+	// IPv6 DNS server
+	// +optional
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+// DNNInfoConfig defines the SMF configuration of a Data Network Name
+type DNNInfoConfig struct {
+	// Data Network Name
+	DNN string `json:"dnn"`
+	// DNS configuration
+	// +optional
+	DNS *DNSConfig `json:"dns,omitempty"`
+}
+
+// SNSSAIInfoConfig defines the DNNs served by the SMF for a slice
+type SNSSAIInfoConfig struct {
+	// SNSSAI configuration
+	SNSSAI SNSSAIConfig `json:"sNssai"`
+	// DNN Info List
+	DNNInfos []DNNInfoConfig `json:"dnnInfos,omitempty"`
+}
+
+// UEIPPoolConfig maps a Data Network Name to the UE IP pools allocated by the SMF
+type UEIPPoolConfig struct {
+	// Data Network Name
+	DNN string `json:"dnn"`
+	// Pools is the list of UE IP pool CIDRs
+	Pools []string `json:"pools"`
+	// UPF is the name of the ULCL instance anchoring these pools.
+	// It may only be omitted when a single UPF anchors PDU sessions.
+	// +optional
+	UPF string `json:"upf,omitempty"`
+}
+
+// SMFPFCPConfig defines the PFCP configuration of the SMF
+type SMFPFCPConfig struct {
+	// Node ID for PFCP
+	// +optional
+	NodeID string `json:"nodeID,omitempty"`
+	// Listen address for PFCP
+	// +optional
+	ListenAddr string `json:"listenAddr,omitempty"`
+	// External address for PFCP
+	// +optional
+	ExternalAddr string `json:"externalAddr,omitempty"`
+	// Heartbeat interval (e.g. 5s)
+	// +optional
+	HeartbeatInterval string `json:"heartbeatInterval,omitempty"`
+	// Interval at which PFCP association failures are reported
+	// +optional
+	AssocFailAlertInterval string `json:"assocFailAlertInterval,omitempty"`
+	// Interval between PFCP association retries
+	// +optional
+	AssocFailRetryInterval string `json:"assocFailRetryInterval,omitempty"`
+}
+
+// SMFConfig defines the SMF-specific configuration
+type SMFConfig struct {
+	// SMF name
+	// +optional
+	SMFName string `json:"smfName,omitempty"`
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// Service Name List
+	// +optional
+	ServiceNameList []string `json:"serviceNameList,omitempty"`
+	// SNSSAI Info List
+	// +optional
+	SNSSAIInfos []SNSSAIInfoConfig `json:"snssaiInfos,omitempty"`
+	// PLMN List
+	// +optional
+	PLMNList []PLMNID `json:"plmnList,omitempty"`
+	// UE IP pools per DNN
+	// +optional
+	UEIPPools []UEIPPoolConfig `json:"ueIpPools,omitempty"`
+	// PFCP configuration
+	// +optional
+	PFCP *SMFPFCPConfig `json:"pfcp,omitempty"`
+	// NRF URI
+	// +optional
+	NRFURI string `json:"nrfUri,omitempty"`
+	// T3591 (PDU session modification command) timer
+	// +optional
+	T3591 *NASTimerConfig `json:"t3591,omitempty"`
+	// T3592 (PDU session release command) timer
+	// +optional
+	T3592 *NASTimerConfig `json:"t3592,omitempty"`
+	// UE routing groups, rendered into uerouting.yaml
+	// +optional
+	UERouting []UERoutingConfig `json:"ueRouting,omitempty"`
+}
+
+// UERoutingConfig defines how a group of UEs is routed through the user plane
+type UERoutingConfig struct {
+	// Name of the routing group
+	Name string `json:"name"`
+	// SUPIs of the UEs in the group
+	// +optional
+	Members []string `json:"members,omitempty"`
+	// Paths taken by traffic to specific destinations
+	// +optional
+	SpecificPaths []SpecificPathConfig `json:"specificPaths,omitempty"`
+}
+
+// SpecificPathConfig defines the UPFs traversed by traffic to a destination
+type SpecificPathConfig struct {
+	// Destination CIDR
+	Dest string `json:"dest"`
+	// Names of the UPF nodes traversed, from the access network to the anchor
+	Path []string `json:"path"`
+}
+
+// SMFSpec defines the configuration for SMF
+type SMFSpec struct {
+	ComponentSpec `json:",inline"`
+	// SMFConfig contains SMF-specific configuration, rendered into smfcfg.yaml
+	// +optional
+	SMFConfig *SMFConfig `json:"smfConfig,omitempty"`
+}
+
+// MongoDBConfig defines how a network function connects to MongoDB
+type MongoDBConfig struct {
+	// Database name
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Connection URL, defaults to the MongoDB managed by the operator
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// NRFConfig defines the NRF-specific configuration
+type NRFConfig struct {
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// Service Name List
+	// +optional
+	ServiceNameList []string `json:"serviceNameList,omitempty"`
+	// MongoDB configuration
+	// +optional
+	MongoDB *MongoDBConfig `json:"mongodb,omitempty"`
+	// Default PLMN ID
+	// +optional
+	DefaultPLMNID *PLMNID `json:"defaultPlmnId,omitempty"`
+}
+
+// NRFSpec defines the configuration for NRF
+type NRFSpec struct {
+	ComponentSpec `json:",inline"`
+	// NRFConfig contains NRF-specific configuration, rendered into nrfcfg.yaml
+	// +optional
+	NRFConfig *NRFConfig `json:"nrfConfig,omitempty"`
+}
+
+// AUSFConfig defines the AUSF-specific configuration
+type AUSFConfig struct {
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// Service Name List
+	// +optional
+	ServiceNameList []string `json:"serviceNameList,omitempty"`
+	// NRF URI
+	// +optional
+	NRFURI string `json:"nrfUri,omitempty"`
+	// PLMN Support List
+	// +optional
+	PLMNSupportList []PLMNID `json:"plmnSupportList,omitempty"`
+	// Group ID
+	// +optional
+	GroupID string `json:"groupId,omitempty"`
+	// Prefix SUPIs with "imsi-" for EAP-AKA'
+	// +optional
+	EAPAKASupiImsiPrefix *bool `json:"eapAkaSupiImsiPrefix,omitempty"`
+}
+
+// AUSFSpec defines the configuration for AUSF
+type AUSFSpec struct {
+	ComponentSpec `json:",inline"`
+	// AUSFConfig contains AUSF-specific configuration, rendered into ausfcfg.yaml
+	// +optional
+	AUSFConfig *AUSFConfig `json:"ausfConfig,omitempty"`
+}
+
+// SUCIProfileConfig defines a SUCI protection scheme profile of the UDM
+type SUCIProfileConfig struct {
+	// Protection scheme (1: Profile A, 2: Profile B)
+	ProtectionScheme int32 `json:"protectionScheme"`
+	// PrivateKeySecretRef selects the Secret key holding the home network private key
+	// (hex). The UDM configuration holding it is written to a Secret rather than to the
+	// ConfigMap of the UDM.
+	PrivateKeySecretRef corev1.SecretKeySelector `json:"privateKeySecretRef"`
+	// Home network public key (hex)
+	PublicKey string `json:"publicKey"`
+}
+
+// UDMConfig defines the UDM-specific configuration
+type UDMConfig struct {
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// Service Name List
+	// +optional
+	ServiceNameList []string `json:"serviceNameList,omitempty"`
+	// NRF URI
+	// +optional
+	NRFURI string `json:"nrfUri,omitempty"`
+	// SUCI profiles
+	// +optional
+	SUCIProfiles []SUCIProfileConfig `json:"suciProfiles,omitempty"`
+}
+
+// UDMSpec defines the configuration for UDM
+type UDMSpec struct {
+	ComponentSpec `json:",inline"`
+	// UDMConfig contains UDM-specific configuration, rendered into udmcfg.yaml
+	// +optional
+	UDMConfig *UDMConfig `json:"udmConfig,omitempty"`
+}
+
+// UDRConfig defines the UDR-specific configuration
+type UDRConfig struct {
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// NRF URI
+	// +optional
+	NRFURI string `json:"nrfUri,omitempty"`
+	// MongoDB configuration
+	// +optional
+	MongoDB *MongoDBConfig `json:"mongodb,omitempty"`
+}
+
+// UDRSpec defines the configuration for UDR
+type UDRSpec struct {
+	ComponentSpec `json:",inline"`
+	// UDRConfig contains UDR-specific configuration, rendered into udrcfg.yaml
+	// +optional
+	UDRConfig *UDRConfig `json:"udrConfig,omitempty"`
+}
+
+// PCFServiceConfig defines a service exposed by the PCF
+type PCFServiceConfig struct {
+	// Service name
+	ServiceName string `json:"serviceName"`
+	// Supported features
+	// +optional
+	SuppFeat string `json:"suppFeat,omitempty"`
+}
+
+// PCFConfig defines the PCF-specific configuration
+type PCFConfig struct {
+	// PCF name
+	// +optional
+	PCFName string `json:"pcfName,omitempty"`
+	// SBI configuration
+	// +optional
+	SBI *SBIConfig `json:"sbi,omitempty"`
+	// NRF URI
+	// +optional
+	NRFURI string `json:"nrfUri,omitempty"`
+	// Service List
+	// +optional
+	ServiceList []PCFServiceConfig `json:"serviceList,omitempty"`
+	// MongoDB configuration
+	// +optional
+	MongoDB *MongoDBConfig `json:"mongodb,omitempty"`
+}
+
+// PCFSpec defines the configuration for PCF
+type PCFSpec struct {
+	ComponentSpec `json:",inline"`
+	// PCFConfig contains PCF-specific configuration, rendered into pcfcfg.yaml
+	// +optional
+	PCFConfig *PCFConfig `json:"pcfConfig,omitempty"`
+}
+
 // NSIInformation defines the Network Slice Instance Information
 type NSIInformation struct {
 	// NRF ID
@@ -137,36 +790,50 @@ type NSSFConfig struct {
 
 // NSSFSpec defines the configuration for NSSF
 type NSSFSpec struct {
-	// Image is the container image to use for the component
-	Image string `json:"image"`
-	// Replicas is the number of replicas to deploy
-	// +optional
-	Replicas *int32 `json:"replicas,omitempty"`
-	// Resources specifies the compute resources for the component
-	// +optional
-	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
-	// Config contains NSSF-specific configuration
+	ComponentSpec `json:",inline"`
+	// NSSFConfig contains NSSF-specific configuration, rendered into nssfcfg.yaml
 	// +optional
-	Config *NSSFConfig `json:"config,omitempty"`
+	NSSFConfig *NSSFConfig `json:"nssfConfig,omitempty"`
 }
 
 // Free5GCSpec defines the desired state of Free5GC
 type Free5GCSpec struct {
+	// Version is the free5gc release to deploy. It selects the default images of
+	// the network functions and the schema of their configuration files.
+	// +kubebuilder:validation:Enum=v3.3.0;v3.4.0
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ImageRegistry is the registry and repository prefix of the default images,
+	// such as registry.example.com/free5gc. Defaults to the free5gc Docker Hub images.
+	// +optional
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// PLMN served by the core, used by every network function unless
+	// overridden in its own configuration
+	// +optional
+	PLMN *PLMNSpec `json:"plmn,omitempty"`
+
+	// Network slices of the core, used by every network function unless
+	// overridden in its own configuration
+	// +optional
+	Slices []SliceSpec `json:"slices,omitempty"`
+
 	// MongoDB configuration
 	// +optional
 	MongoDB *MongoDBSpec `json:"mongodb,omitempty"`
 
 	// NRF (Network Repository Function) configuration
 	// +optional
-	NRF *ComponentSpec `json:"nrf,omitempty"`
+	NRF *NRFSpec `json:"nrf,omitempty"`
 
 	// AMF (Access and Mobility Management Function) configuration
 	// +optional
-	AMF *ComponentSpec `json:"amf,omitempty"`
+	AMF *AMFSpec `json:"amf,omitempty"`
 
 	// SMF (Session Management Function) configuration
 	// +optional
-	SMF *ComponentSpec `json:"smf,omitempty"`
+	SMF *SMFSpec `json:"smf,omitempty"`
 
 	// UPF (User Plane Function) configuration
 	// +optional
@@ -174,7 +841,7 @@ type Free5GCSpec struct {
 
 	// AUSF (Authentication Server Function) configuration
 	// +optional
-	AUSF *ComponentSpec `json:"ausf,omitempty"`
+	AUSF *AUSFSpec `json:"ausf,omitempty"`
 
 	// NSSF (Network Slice Selection Function) configuration
 	// +optional
@@ -182,15 +849,15 @@ type Free5GCSpec struct {
 
 	// PCF (Policy Control Function) configuration
 	// +optional
-	PCF *ComponentSpec `json:"pcf,omitempty"`
+	PCF *PCFSpec `json:"pcf,omitempty"`
 
 	// UDM (Unified Data Management) configuration
 	// +optional
-	UDM *ComponentSpec `json:"udm,omitempty"`
+	UDM *UDMSpec `json:"udm,omitempty"`
 
 	// UDR (Unified Data Repository) configuration
 	// +optional
-	UDR *ComponentSpec `json:"udr,omitempty"`
+	UDR *UDRSpec `json:"udr,omitempty"`
 
 	// N3IWF (Non-3GPP InterWorking Function) configuration
 	// +optional
@@ -213,12 +880,16 @@ type MongoDBSpec struct {
 	// URI is the connection URI for external MongoDB
 	// +optional
 	URI string `json:"uri,omitempty"`
-	// Image is the container image to use for MongoDB
+	// Image is the container image to use for MongoDB, defaults to the image
+	// the free5gc version is tested with
 	// +optional
 	Image string `json:"image,omitempty"`
 	// Storage configuration for MongoDB
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
+	// Probes overrides the thresholds of the probes of MongoDB
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
 }
 
 // StorageSpec defines the storage configuration
@@ -228,24 +899,31 @@ type StorageSpec struct {
 	// StorageClassName is the name of the storage class to use
 	// +optional
 	StorageClassName string `json:"storageClassName,omitempty"`
+	// RetentionPolicy is what happens to the PersistentVolumeClaim when the Free5GC is
+	// deleted: Delete (default) deletes it with the Free5GC, Retain keeps it along with
+	// the subscriber data, for a later Free5GC of the same name to reuse
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	RetentionPolicy string `json:"retentionPolicy,omitempty"`
 }
 
+const (
+	// RetentionPolicyDelete deletes the MongoDB PersistentVolumeClaim with the Free5GC
+	RetentionPolicyDelete = "Delete"
+	// RetentionPolicyRetain keeps the MongoDB PersistentVolumeClaim once the Free5GC is
+	// deleted
+	RetentionPolicyRetain = "Retain"
+)
+
 // UPFSpec defines the configuration for UPF
 type UPFSpec struct {
-	// Image is the container image to use for the component
-	Image string `json:"image"`
-	// Replicas is the number of replicas to deploy
-	// +optional
-	Replicas *int32 `json:"replicas,omitempty"`
-	// Resources specifies the compute resources for the component
-	// +optional
-	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	ComponentSpec `json:",inline"`
 	// ULCL (Uplink Classifier) configuration
 	// +optional
 	ULCL *ULCLSpec `json:"ulcl,omitempty"`
-	// Config contains UPF-specific configuration
+	// UPFConfig contains UPF-specific configuration, rendered into upfcfg.yaml
 	// +optional
-	Config *UPFConfig `json:"config,omitempty"`
+	UPFConfig *UPFConfig `json:"upfConfig,omitempty"`
 }
 
 // UPFConfig defines the UPF-specific configuration
@@ -256,6 +934,9 @@ type UPFConfig struct {
 	// GTPU configuration
 	// +optional
 	GTPU *GTPUConfig `json:"gtpu,omitempty"`
+	// DNN List served by the UPF
+	// +optional
+	DNNList []UPFDNNConfig `json:"dnnList,omitempty"`
 }
 
 // PFCPConfig defines the PFCP configuration
@@ -274,8 +955,39 @@ type PFCPConfig struct {
 type GTPUConfig struct {
 	// Forwarder type
 	Forwarder string `json:"forwarder,omitempty"`
+	// Interface name, used for the entries of IfList that do not set one
+	IfName string `json:"ifname,omitempty"`
+	// GTP-U interface list
+	// +optional
+	IfList []GTPUInterfaceConfig `json:"ifList,omitempty"`
+}
+
+// GTPUInterfaceConfig defines a GTP-U interface of the UPF
+type GTPUInterfaceConfig struct {
+	// Address for GTP-U
+	Addr string `json:"addr"`
+	// Interface type (N3, N9)
+	Type string `json:"type"`
+	// Name advertised for this interface
+	// +optional
+	Name string `json:"name,omitempty"`
 	// Interface name
+	// +optional
 	IfName string `json:"ifname,omitempty"`
+	// MTU of the interface
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+}
+
+// UPFDNNConfig defines a Data Network served by the UPF
+type UPFDNNConfig struct {
+	// Data Network Name
+	DNN string `json:"dnn"`
+	// UE IP CIDR of the Data Network
+	CIDR string `json:"cidr"`
+	// Interface used for NAT towards the Data Network
+	// +optional
+	NATIfName string `json:"natifname,omitempty"`
 }
 
 // ULCLSpec defines the configuration for ULCL
@@ -293,8 +1005,19 @@ type UPFInstance struct {
 	Name string `json:"name"`
 	// Configuration for this UPF instance
 	ComponentSpec `json:",inline"`
+	// UPFConfig overrides the UPF configuration for this instance
+	// +optional
+	UPFConfig *UPFConfig `json:"upfConfig,omitempty"`
 }
 
+// ConditionNetworksAttached is the type of the condition reporting whether the running
+// pods of the components are attached to all their networks
+const ConditionNetworksAttached = "NetworksAttached"
+
+// ConditionProgressing is the type of the condition reporting whether the components
+// are still starting, and which startup tier waits for its dependencies to be ready
+const ConditionProgressing = "Progressing"
+
 // Free5GCStatus defines the observed state of Free5GC
 type Free5GCStatus struct {
 	// Conditions represent the latest available observations of the Free5GC state
@@ -308,6 +1031,21 @@ type Free5GCStatus struct {
 	// Components represents the status of each Free5GC component
 	// +optional
 	Components map[string]ComponentStatus `json:"components,omitempty"`
+
+	// Addresses are the addresses allocated to the components from the CIDR of
+	// their networks, kept as long as the components stay attached to the networks
+	// +optional
+	Addresses []AddressAllocation `json:"addresses,omitempty"`
+}
+
+// AddressAllocation is an address allocated to a component on a network
+type AddressAllocation struct {
+	// Component is the name of the component, such as upf-ulcl1 for a ULCL instance
+	Component string `json:"component"`
+	// Network is the reference point of the network, such as N3
+	Network string `json:"network"`
+	// Address is the IP address allocated to the component
+	Address string `json:"address"`
 }
 
 // ComponentStatus defines the observed state of a component
@@ -323,10 +1061,62 @@ type ComponentStatus struct {
 	// Total replicas
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
+	// Pods are the networks attached to the running pods of the component, as
+	// reported by Multus
+	// +optional
+	Pods []PodNetworkStatus `json:"pods,omitempty"`
+	// Endpoints are the addresses the component is reachable on from outside the cluster
+	// +optional
+	Endpoints []ExternalEndpoint `json:"endpoints,omitempty"`
+}
+
+// ExternalEndpoint defines an address a component is reachable on from outside the
+// cluster
+type ExternalEndpoint struct {
+	// Name of the port, or ingress for the Ingress host
+	Name string `json:"name"`
+	// Host is the address of the load balancer or the Ingress host. It is empty for the
+	// ports of NodePort Services, which are open on every node.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Port number
+	Port int32 `json:"port"`
+	// Protocol of the port
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+}
+
+// PodNetworkStatus defines the networks attached to a pod
+type PodNetworkStatus struct {
+	// Name of the pod
+	Name string `json:"name"`
+	// Networks are the interfaces of the pod on the networks of the reference points
+	// +optional
+	Networks []NetworkInterfaceStatus `json:"networks,omitempty"`
+	// MissingNetworks are the reference points of the networks expected on the pod
+	// but not attached to it
+	// +optional
+	MissingNetworks []string `json:"missingNetworks,omitempty"`
+}
+
+// NetworkInterfaceStatus defines the interface of a pod on a network
+type NetworkInterfaceStatus struct {
+	// Network is the reference point of the network, such as N3
+	Network string `json:"network"`
+	// Interface is the name of the interface in the pod
+	// +optional
+	Interface string `json:"interface,omitempty"`
+	// IPs are the addresses assigned to the interface
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+	// MAC is the MAC address of the interface
+	// +optional
+	MAC string `json:"mac,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // Free5GC is the Schema for the free5gcs API
 type Free5GC struct {
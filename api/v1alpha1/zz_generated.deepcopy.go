@@ -21,16 +21,361 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMFConfig) DeepCopyInto(out *AMFConfig) {
+	*out = *in
+	if in.NetworkName != nil {
+		in, out := &in.NetworkName, &out.NetworkName
+		*out = new(NetworkNameConfig)
+		**out = **in
+	}
+	if in.NGAPIPList != nil {
+		in, out := &in.NGAPIPList, &out.NGAPIPList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceNameList != nil {
+		in, out := &in.ServiceNameList, &out.ServiceNameList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServedGUAMIList != nil {
+		in, out := &in.ServedGUAMIList, &out.ServedGUAMIList
+		*out = make([]GUAMIConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.SupportTAIList != nil {
+		in, out := &in.SupportTAIList, &out.SupportTAIList
+		*out = make([]TAIConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.PLMNSupportList != nil {
+		in, out := &in.PLMNSupportList, &out.PLMNSupportList
+		*out = make([]PLMNSupportConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SupportDNNList != nil {
+		in, out := &in.SupportDNNList, &out.SupportDNNList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(SecurityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NASTimers != nil {
+		in, out := &in.NASTimers, &out.NASTimers
+		*out = new(NASTimersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMFConfig.
+func (in *AMFConfig) DeepCopy() *AMFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AMFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMFSpec) DeepCopyInto(out *AMFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.AMFConfig != nil {
+		in, out := &in.AMFConfig, &out.AMFConfig
+		*out = new(AMFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMFSpec.
+func (in *AMFSpec) DeepCopy() *AMFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AMFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AUSFConfig) DeepCopyInto(out *AUSFConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceNameList != nil {
+		in, out := &in.ServiceNameList, &out.ServiceNameList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PLMNSupportList != nil {
+		in, out := &in.PLMNSupportList, &out.PLMNSupportList
+		*out = make([]PLMNID, len(*in))
+		copy(*out, *in)
+	}
+	if in.EAPAKASupiImsiPrefix != nil {
+		in, out := &in.EAPAKASupiImsiPrefix, &out.EAPAKASupiImsiPrefix
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AUSFConfig.
+func (in *AUSFConfig) DeepCopy() *AUSFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AUSFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AUSFSpec) DeepCopyInto(out *AUSFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.AUSFConfig != nil {
+		in, out := &in.AUSFConfig, &out.AUSFConfig
+		*out = new(AUSFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AUSFSpec.
+func (in *AUSFSpec) DeepCopy() *AUSFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AUSFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressAllocation) DeepCopyInto(out *AddressAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressAllocation.
+func (in *AddressAllocation) DeepCopy() *AddressAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]PortSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = make([]ConfigFileSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PodNetworkStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]ExternalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigFileSource) DeepCopyInto(out *ConfigFileSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigFileSource.
+func (in *ConfigFileSource) DeepCopy() *ConfigFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNNInfoConfig) DeepCopyInto(out *DNNInfoConfig) {
+	*out = *in
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNNInfoConfig.
+func (in *DNNInfoConfig) DeepCopy() *DNNInfoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNNInfoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSConfig.
+func (in *DNSConfig) DeepCopy() *DNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeSpec.
+func (in *ExposeSpec) DeepCopy() *ExposeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalEndpoint) DeepCopyInto(out *ExternalEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalEndpoint.
+func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Free5GC) DeepCopyInto(out *Free5GC) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Free5GC.
@@ -86,6 +431,79 @@ func (in *Free5GCList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Free5GCSpec) DeepCopyInto(out *Free5GCSpec) {
 	*out = *in
+	if in.PLMN != nil {
+		in, out := &in.PLMN, &out.PLMN
+		*out = new(PLMNSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Slices != nil {
+		in, out := &in.Slices, &out.Slices
+		*out = make([]SliceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(MongoDBSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NRF != nil {
+		in, out := &in.NRF, &out.NRF
+		*out = new(NRFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMF != nil {
+		in, out := &in.AMF, &out.AMF
+		*out = new(AMFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMF != nil {
+		in, out := &in.SMF, &out.SMF
+		*out = new(SMFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UPF != nil {
+		in, out := &in.UPF, &out.UPF
+		*out = new(UPFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AUSF != nil {
+		in, out := &in.AUSF, &out.AUSF
+		*out = new(AUSFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NSSF != nil {
+		in, out := &in.NSSF, &out.NSSF
+		*out = new(NSSFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PCF != nil {
+		in, out := &in.PCF, &out.PCF
+		*out = new(PCFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UDM != nil {
+		in, out := &in.UDM, &out.UDM
+		*out = new(UDMSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UDR != nil {
+		in, out := &in.UDR, &out.UDR
+		*out = new(UDRSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.N3IWF != nil {
+		in, out := &in.N3IWF, &out.N3IWF
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebUI != nil {
+		in, out := &in.WebUI, &out.WebUI
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Network.DeepCopyInto(&out.Network)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Free5GCSpec.
@@ -101,6 +519,26 @@ func (in *Free5GCSpec) DeepCopy() *Free5GCSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Free5GCStatus) DeepCopyInto(out *Free5GCStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.MongoDB.DeepCopyInto(&out.MongoDB)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]ComponentStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]AddressAllocation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Free5GCStatus.
@@ -112,3 +550,1244 @@ func (in *Free5GCStatus) DeepCopy() *Free5GCStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GTPUConfig) DeepCopyInto(out *GTPUConfig) {
+	*out = *in
+	if in.IfList != nil {
+		in, out := &in.IfList, &out.IfList
+		*out = make([]GTPUInterfaceConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GTPUConfig.
+func (in *GTPUConfig) DeepCopy() *GTPUConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GTPUConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GTPUInterfaceConfig) DeepCopyInto(out *GTPUInterfaceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GTPUInterfaceConfig.
+func (in *GTPUInterfaceConfig) DeepCopy() *GTPUInterfaceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GTPUInterfaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GUAMIConfig) DeepCopyInto(out *GUAMIConfig) {
+	*out = *in
+	out.PLMNID = in.PLMNID
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GUAMIConfig.
+func (in *GUAMIConfig) DeepCopy() *GUAMIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GUAMIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBConfig) DeepCopyInto(out *MongoDBConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBConfig.
+func (in *MongoDBConfig) DeepCopy() *MongoDBConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSpec) DeepCopyInto(out *MongoDBSpec) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		**out = **in
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSpec.
+func (in *MongoDBSpec) DeepCopy() *MongoDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NASTimerConfig) DeepCopyInto(out *NASTimerConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NASTimerConfig.
+func (in *NASTimerConfig) DeepCopy() *NASTimerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NASTimerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NASTimersConfig) DeepCopyInto(out *NASTimersConfig) {
+	*out = *in
+	if in.T3513 != nil {
+		in, out := &in.T3513, &out.T3513
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.T3522 != nil {
+		in, out := &in.T3522, &out.T3522
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.T3550 != nil {
+		in, out := &in.T3550, &out.T3550
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.T3560 != nil {
+		in, out := &in.T3560, &out.T3560
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.T3565 != nil {
+		in, out := &in.T3565, &out.T3565
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.T3570 != nil {
+		in, out := &in.T3570, &out.T3570
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NASTimersConfig.
+func (in *NASTimersConfig) DeepCopy() *NASTimersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NASTimersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NRFConfig) DeepCopyInto(out *NRFConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceNameList != nil {
+		in, out := &in.ServiceNameList, &out.ServiceNameList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(MongoDBConfig)
+		**out = **in
+	}
+	if in.DefaultPLMNID != nil {
+		in, out := &in.DefaultPLMNID, &out.DefaultPLMNID
+		*out = new(PLMNID)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NRFConfig.
+func (in *NRFConfig) DeepCopy() *NRFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NRFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NRFSpec) DeepCopyInto(out *NRFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.NRFConfig != nil {
+		in, out := &in.NRFConfig, &out.NRFConfig
+		*out = new(NRFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NRFSpec.
+func (in *NRFSpec) DeepCopy() *NRFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NRFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSIConfig) DeepCopyInto(out *NSIConfig) {
+	*out = *in
+	if in.SNSSAI != nil {
+		in, out := &in.SNSSAI, &out.SNSSAI
+		*out = new(SNSSAIConfig)
+		**out = **in
+	}
+	if in.NSIInformationList != nil {
+		in, out := &in.NSIInformationList, &out.NSIInformationList
+		*out = make([]NSIInformation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSIConfig.
+func (in *NSIConfig) DeepCopy() *NSIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NSIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSIInformation) DeepCopyInto(out *NSIInformation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSIInformation.
+func (in *NSIInformation) DeepCopy() *NSIInformation {
+	if in == nil {
+		return nil
+	}
+	out := new(NSIInformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSSFConfig) DeepCopyInto(out *NSSFConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceNameList != nil {
+		in, out := &in.ServiceNameList, &out.ServiceNameList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NSIList != nil {
+		in, out := &in.NSIList, &out.NSIList
+		*out = make([]NSIConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSSFConfig.
+func (in *NSSFConfig) DeepCopy() *NSSFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NSSFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSSFSpec) DeepCopyInto(out *NSSFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.NSSFConfig != nil {
+		in, out := &in.NSSFConfig, &out.NSSFConfig
+		*out = new(NSSFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSSFSpec.
+func (in *NSSFSpec) DeepCopy() *NSSFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NSSFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAttachmentConfig) DeepCopyInto(out *NetworkAttachmentConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAttachmentConfig.
+func (in *NetworkAttachmentConfig) DeepCopy() *NetworkAttachmentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachmentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceStatus) DeepCopyInto(out *NetworkInterfaceStatus) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceStatus.
+func (in *NetworkInterfaceStatus) DeepCopy() *NetworkInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkNameConfig) DeepCopyInto(out *NetworkNameConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkNameConfig.
+func (in *NetworkNameConfig) DeepCopy() *NetworkNameConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkNameConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.N2Network != nil {
+		in, out := &in.N2Network, &out.N2Network
+		*out = new(NetworkAttachmentConfig)
+		**out = **in
+	}
+	if in.N3Network != nil {
+		in, out := &in.N3Network, &out.N3Network
+		*out = new(NetworkAttachmentConfig)
+		**out = **in
+	}
+	if in.N4Network != nil {
+		in, out := &in.N4Network, &out.N4Network
+		*out = new(NetworkAttachmentConfig)
+		**out = **in
+	}
+	if in.N6Network != nil {
+		in, out := &in.N6Network, &out.N6Network
+		*out = new(NetworkAttachmentConfig)
+		**out = **in
+	}
+	if in.N9Network != nil {
+		in, out := &in.N9Network, &out.N9Network
+		*out = new(NetworkAttachmentConfig)
+		**out = **in
+	}
+	if in.NWuNetwork != nil {
+		in, out := &in.NWuNetwork, &out.NWuNetwork
+		*out = new(NetworkAttachmentConfig)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PCFConfig) DeepCopyInto(out *PCFConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceList != nil {
+		in, out := &in.ServiceList, &out.ServiceList
+		*out = make([]PCFServiceConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(MongoDBConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PCFConfig.
+func (in *PCFConfig) DeepCopy() *PCFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PCFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PCFServiceConfig) DeepCopyInto(out *PCFServiceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PCFServiceConfig.
+func (in *PCFServiceConfig) DeepCopy() *PCFServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PCFServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PCFSpec) DeepCopyInto(out *PCFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.PCFConfig != nil {
+		in, out := &in.PCFConfig, &out.PCFConfig
+		*out = new(PCFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PCFSpec.
+func (in *PCFSpec) DeepCopy() *PCFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PCFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PFCPConfig) DeepCopyInto(out *PFCPConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PFCPConfig.
+func (in *PFCPConfig) DeepCopy() *PFCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PFCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PLMNID) DeepCopyInto(out *PLMNID) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PLMNID.
+func (in *PLMNID) DeepCopy() *PLMNID {
+	if in == nil {
+		return nil
+	}
+	out := new(PLMNID)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PLMNSpec) DeepCopyInto(out *PLMNSpec) {
+	*out = *in
+	if in.PLMNIDs != nil {
+		in, out := &in.PLMNIDs, &out.PLMNIDs
+		*out = make([]PLMNID, len(*in))
+		copy(*out, *in)
+	}
+	if in.TAIs != nil {
+		in, out := &in.TAIs, &out.TAIs
+		*out = make([]TAIConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkName != nil {
+		in, out := &in.NetworkName, &out.NetworkName
+		*out = new(NetworkNameConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PLMNSpec.
+func (in *PLMNSpec) DeepCopy() *PLMNSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PLMNSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PLMNSupportConfig) DeepCopyInto(out *PLMNSupportConfig) {
+	*out = *in
+	out.PLMNID = in.PLMNID
+	if in.SNSSAIList != nil {
+		in, out := &in.SNSSAIList, &out.SNSSAIList
+		*out = make([]SNSSAIConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PLMNSupportConfig.
+func (in *PLMNSupportConfig) DeepCopy() *PLMNSupportConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PLMNSupportConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNetworkStatus) DeepCopyInto(out *PodNetworkStatus) {
+	*out = *in
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]NetworkInterfaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MissingNetworks != nil {
+		in, out := &in.MissingNetworks, &out.MissingNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkStatus.
+func (in *PodNetworkStatus) DeepCopy() *PodNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortSpec) DeepCopyInto(out *PortSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortSpec.
+func (in *PortSpec) DeepCopy() *PortSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbesSpec) DeepCopyInto(out *ProbesSpec) {
+	*out = *in
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesSpec.
+func (in *ProbesSpec) DeepCopy() *ProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBIConfig) DeepCopyInto(out *SBIConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBIConfig.
+func (in *SBIConfig) DeepCopy() *SBIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SBIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMFConfig) DeepCopyInto(out *SMFConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceNameList != nil {
+		in, out := &in.ServiceNameList, &out.ServiceNameList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SNSSAIInfos != nil {
+		in, out := &in.SNSSAIInfos, &out.SNSSAIInfos
+		*out = make([]SNSSAIInfoConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PLMNList != nil {
+		in, out := &in.PLMNList, &out.PLMNList
+		*out = make([]PLMNID, len(*in))
+		copy(*out, *in)
+	}
+	if in.UEIPPools != nil {
+		in, out := &in.UEIPPools, &out.UEIPPools
+		*out = make([]UEIPPoolConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PFCP != nil {
+		in, out := &in.PFCP, &out.PFCP
+		*out = new(SMFPFCPConfig)
+		**out = **in
+	}
+	if in.T3591 != nil {
+		in, out := &in.T3591, &out.T3591
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.T3592 != nil {
+		in, out := &in.T3592, &out.T3592
+		*out = new(NASTimerConfig)
+		**out = **in
+	}
+	if in.UERouting != nil {
+		in, out := &in.UERouting, &out.UERouting
+		*out = make([]UERoutingConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMFConfig.
+func (in *SMFConfig) DeepCopy() *SMFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SMFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMFPFCPConfig) DeepCopyInto(out *SMFPFCPConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMFPFCPConfig.
+func (in *SMFPFCPConfig) DeepCopy() *SMFPFCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SMFPFCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMFSpec) DeepCopyInto(out *SMFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.SMFConfig != nil {
+		in, out := &in.SMFConfig, &out.SMFConfig
+		*out = new(SMFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMFSpec.
+func (in *SMFSpec) DeepCopy() *SMFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SMFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNSSAIConfig) DeepCopyInto(out *SNSSAIConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSSAIConfig.
+func (in *SNSSAIConfig) DeepCopy() *SNSSAIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SNSSAIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNSSAIInfoConfig) DeepCopyInto(out *SNSSAIInfoConfig) {
+	*out = *in
+	out.SNSSAI = in.SNSSAI
+	if in.DNNInfos != nil {
+		in, out := &in.DNNInfos, &out.DNNInfos
+		*out = make([]DNNInfoConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSSAIInfoConfig.
+func (in *SNSSAIInfoConfig) DeepCopy() *SNSSAIInfoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SNSSAIInfoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SUCIProfileConfig) DeepCopyInto(out *SUCIProfileConfig) {
+	*out = *in
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SUCIProfileConfig.
+func (in *SUCIProfileConfig) DeepCopy() *SUCIProfileConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SUCIProfileConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityConfig) DeepCopyInto(out *SecurityConfig) {
+	*out = *in
+	if in.IntegrityOrder != nil {
+		in, out := &in.IntegrityOrder, &out.IntegrityOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CipheringOrder != nil {
+		in, out := &in.CipheringOrder, &out.CipheringOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfig.
+func (in *SecurityConfig) DeepCopy() *SecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SliceDNNSpec) DeepCopyInto(out *SliceDNNSpec) {
+	*out = *in
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SliceDNNSpec.
+func (in *SliceDNNSpec) DeepCopy() *SliceDNNSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SliceDNNSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SliceSpec) DeepCopyInto(out *SliceSpec) {
+	*out = *in
+	out.SNSSAIConfig = in.SNSSAIConfig
+	if in.DNNs != nil {
+		in, out := &in.DNNs, &out.DNNs
+		*out = make([]SliceDNNSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UPFs != nil {
+		in, out := &in.UPFs, &out.UPFs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SliceSpec.
+func (in *SliceSpec) DeepCopy() *SliceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SliceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecificPathConfig) DeepCopyInto(out *SpecificPathConfig) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificPathConfig.
+func (in *SpecificPathConfig) DeepCopy() *SpecificPathConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecificPathConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
+func (in *StorageSpec) DeepCopy() *StorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TAIConfig) DeepCopyInto(out *TAIConfig) {
+	*out = *in
+	out.PLMNID = in.PLMNID
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TAIConfig.
+func (in *TAIConfig) DeepCopy() *TAIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TAIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDMConfig) DeepCopyInto(out *UDMConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.ServiceNameList != nil {
+		in, out := &in.ServiceNameList, &out.ServiceNameList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SUCIProfiles != nil {
+		in, out := &in.SUCIProfiles, &out.SUCIProfiles
+		*out = make([]SUCIProfileConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDMConfig.
+func (in *UDMConfig) DeepCopy() *UDMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UDMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDMSpec) DeepCopyInto(out *UDMSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.UDMConfig != nil {
+		in, out := &in.UDMConfig, &out.UDMConfig
+		*out = new(UDMConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDMSpec.
+func (in *UDMSpec) DeepCopy() *UDMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UDMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDRConfig) DeepCopyInto(out *UDRConfig) {
+	*out = *in
+	if in.SBI != nil {
+		in, out := &in.SBI, &out.SBI
+		*out = new(SBIConfig)
+		**out = **in
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(MongoDBConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDRConfig.
+func (in *UDRConfig) DeepCopy() *UDRConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UDRConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDRSpec) DeepCopyInto(out *UDRSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.UDRConfig != nil {
+		in, out := &in.UDRConfig, &out.UDRConfig
+		*out = new(UDRConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDRSpec.
+func (in *UDRSpec) DeepCopy() *UDRSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UDRSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UEIPPoolConfig) DeepCopyInto(out *UEIPPoolConfig) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UEIPPoolConfig.
+func (in *UEIPPoolConfig) DeepCopy() *UEIPPoolConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UEIPPoolConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UERoutingConfig) DeepCopyInto(out *UERoutingConfig) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SpecificPaths != nil {
+		in, out := &in.SpecificPaths, &out.SpecificPaths
+		*out = make([]SpecificPathConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UERoutingConfig.
+func (in *UERoutingConfig) DeepCopy() *UERoutingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UERoutingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ULCLSpec) DeepCopyInto(out *ULCLSpec) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]UPFInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ULCLSpec.
+func (in *ULCLSpec) DeepCopy() *ULCLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ULCLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UPFConfig) DeepCopyInto(out *UPFConfig) {
+	*out = *in
+	if in.PFCP != nil {
+		in, out := &in.PFCP, &out.PFCP
+		*out = new(PFCPConfig)
+		**out = **in
+	}
+	if in.GTPU != nil {
+		in, out := &in.GTPU, &out.GTPU
+		*out = new(GTPUConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNNList != nil {
+		in, out := &in.DNNList, &out.DNNList
+		*out = make([]UPFDNNConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UPFConfig.
+func (in *UPFConfig) DeepCopy() *UPFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UPFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UPFDNNConfig) DeepCopyInto(out *UPFDNNConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UPFDNNConfig.
+func (in *UPFDNNConfig) DeepCopy() *UPFDNNConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UPFDNNConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UPFInstance) DeepCopyInto(out *UPFInstance) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.UPFConfig != nil {
+		in, out := &in.UPFConfig, &out.UPFConfig
+		*out = new(UPFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UPFInstance.
+func (in *UPFInstance) DeepCopy() *UPFInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(UPFInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UPFSpec) DeepCopyInto(out *UPFSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.ULCL != nil {
+		in, out := &in.ULCL, &out.ULCL
+		*out = new(ULCLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UPFConfig != nil {
+		in, out := &in.UPFConfig, &out.UPFConfig
+		*out = new(UPFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UPFSpec.
+func (in *UPFSpec) DeepCopy() *UPFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UPFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
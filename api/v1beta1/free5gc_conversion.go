@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// ConvertTo converts this Free5GC to the Hub version (v1alpha1). The ULCL topology is
+// held by the UPF in v1alpha1, which is created when only an enabled topology is set. A
+// disabled topology without UPF is dropped, as it would otherwise deploy a UPF.
+func (src *Free5GC) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Free5GC)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec = v1alpha1.Free5GCSpec{
+		Version:       src.Spec.Version,
+		ImageRegistry: src.Spec.ImageRegistry,
+		PLMN:          src.Spec.PLMN,
+		Slices:        src.Spec.Slices,
+		MongoDB:       src.Spec.MongoDB,
+		Network:       src.Spec.Network,
+	}
+
+	nfs := src.Spec.NetworkFunctions
+	if nrf := nfs.NRF; nrf != nil {
+		dst.Spec.NRF = &v1alpha1.NRFSpec{ComponentSpec: nrf.NetworkFunctionSpec.toComponent(), NRFConfig: nrf.Config}
+	}
+	if amf := nfs.AMF; amf != nil {
+		dst.Spec.AMF = &v1alpha1.AMFSpec{ComponentSpec: amf.NetworkFunctionSpec.toComponent(), AMFConfig: amf.Config}
+	}
+	if smf := nfs.SMF; smf != nil {
+		dst.Spec.SMF = &v1alpha1.SMFSpec{ComponentSpec: smf.NetworkFunctionSpec.toComponent(), SMFConfig: smf.Config}
+	}
+	if upf := nfs.UPF; upf != nil {
+		dst.Spec.UPF = &v1alpha1.UPFSpec{ComponentSpec: upf.NetworkFunctionSpec.toComponent(), UPFConfig: upf.Config}
+	}
+	if ausf := nfs.AUSF; ausf != nil {
+		dst.Spec.AUSF = &v1alpha1.AUSFSpec{ComponentSpec: ausf.NetworkFunctionSpec.toComponent(), AUSFConfig: ausf.Config}
+	}
+	if nssf := nfs.NSSF; nssf != nil {
+		dst.Spec.NSSF = &v1alpha1.NSSFSpec{ComponentSpec: nssf.NetworkFunctionSpec.toComponent(), NSSFConfig: nssf.Config}
+	}
+	if pcf := nfs.PCF; pcf != nil {
+		dst.Spec.PCF = &v1alpha1.PCFSpec{ComponentSpec: pcf.NetworkFunctionSpec.toComponent(), PCFConfig: pcf.Config}
+	}
+	if udm := nfs.UDM; udm != nil {
+		dst.Spec.UDM = &v1alpha1.UDMSpec{ComponentSpec: udm.NetworkFunctionSpec.toComponent(), UDMConfig: udm.Config}
+	}
+	if udr := nfs.UDR; udr != nil {
+		dst.Spec.UDR = &v1alpha1.UDRSpec{ComponentSpec: udr.NetworkFunctionSpec.toComponent(), UDRConfig: udr.Config}
+	}
+	if n3iwf := nfs.N3IWF; n3iwf != nil {
+		component := n3iwf.toComponent()
+		dst.Spec.N3IWF = &component
+	}
+	if webui := nfs.WebUI; webui != nil {
+		component := webui.toComponent()
+		dst.Spec.WebUI = &component
+	}
+
+	if topology := src.Spec.Topology; topology != nil && topology.ULCL != nil &&
+		(dst.Spec.UPF != nil || topology.ULCL.Enabled) {
+		if dst.Spec.UPF == nil {
+			dst.Spec.UPF = &v1alpha1.UPFSpec{}
+		}
+		ulcl := &v1alpha1.ULCLSpec{Enabled: topology.ULCL.Enabled}
+		for _, instance := range topology.ULCL.Instances {
+			ulcl.Instances = append(ulcl.Instances, v1alpha1.UPFInstance{
+				Name:          instance.Name,
+				ComponentSpec: instance.NetworkFunctionSpec.toComponent(),
+				UPFConfig:     instance.Config,
+			})
+		}
+		dst.Spec.UPF.ULCL = ulcl
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version. A UPF only holding
+// the ULCL topology is left out, as ConvertTo creates it from the topology.
+func (dst *Free5GC) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Free5GC)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec = Free5GCSpec{
+		Version:       src.Spec.Version,
+		ImageRegistry: src.Spec.ImageRegistry,
+		PLMN:          src.Spec.PLMN,
+		Slices:        src.Spec.Slices,
+		MongoDB:       src.Spec.MongoDB,
+		Network:       src.Spec.Network,
+	}
+
+	nfs := &dst.Spec.NetworkFunctions
+	if nrf := src.Spec.NRF; nrf != nil {
+		nfs.NRF = &NRFSpec{NetworkFunctionSpec: fromComponent(nrf.ComponentSpec), Config: nrf.NRFConfig}
+	}
+	if amf := src.Spec.AMF; amf != nil {
+		nfs.AMF = &AMFSpec{NetworkFunctionSpec: fromComponent(amf.ComponentSpec), Config: amf.AMFConfig}
+	}
+	if smf := src.Spec.SMF; smf != nil {
+		nfs.SMF = &SMFSpec{NetworkFunctionSpec: fromComponent(smf.ComponentSpec), Config: smf.SMFConfig}
+	}
+	if upf := src.Spec.UPF; upf != nil && !holdsTopologyOnly(upf) {
+		nfs.UPF = &UPFSpec{NetworkFunctionSpec: fromComponent(upf.ComponentSpec), Config: upf.UPFConfig}
+	}
+	if ausf := src.Spec.AUSF; ausf != nil {
+		nfs.AUSF = &AUSFSpec{NetworkFunctionSpec: fromComponent(ausf.ComponentSpec), Config: ausf.AUSFConfig}
+	}
+	if nssf := src.Spec.NSSF; nssf != nil {
+		nfs.NSSF = &NSSFSpec{NetworkFunctionSpec: fromComponent(nssf.ComponentSpec), Config: nssf.NSSFConfig}
+	}
+	if pcf := src.Spec.PCF; pcf != nil {
+		nfs.PCF = &PCFSpec{NetworkFunctionSpec: fromComponent(pcf.ComponentSpec), Config: pcf.PCFConfig}
+	}
+	if udm := src.Spec.UDM; udm != nil {
+		nfs.UDM = &UDMSpec{NetworkFunctionSpec: fromComponent(udm.ComponentSpec), Config: udm.UDMConfig}
+	}
+	if udr := src.Spec.UDR; udr != nil {
+		nfs.UDR = &UDRSpec{NetworkFunctionSpec: fromComponent(udr.ComponentSpec), Config: udr.UDRConfig}
+	}
+	if n3iwf := src.Spec.N3IWF; n3iwf != nil {
+		nf := fromComponent(*n3iwf)
+		nfs.N3IWF = &nf
+	}
+	if webui := src.Spec.WebUI; webui != nil {
+		nf := fromComponent(*webui)
+		nfs.WebUI = &nf
+	}
+
+	if upf := src.Spec.UPF; upf != nil && upf.ULCL != nil {
+		ulcl := &ULCLSpec{Enabled: upf.ULCL.Enabled}
+		for _, instance := range upf.ULCL.Instances {
+			ulcl.Instances = append(ulcl.Instances, UPFInstanceSpec{
+				Name:                instance.Name,
+				NetworkFunctionSpec: fromComponent(instance.ComponentSpec),
+				Config:              instance.UPFConfig,
+			})
+		}
+		dst.Spec.Topology = &TopologySpec{ULCL: ulcl}
+	}
+	return nil
+}
+
+// holdsTopologyOnly reports whether a v1alpha1 UPF only holds an enabled ULCL topology
+func holdsTopologyOnly(upf *v1alpha1.UPFSpec) bool {
+	return upf.ULCL != nil && upf.ULCL.Enabled && upf.UPFConfig == nil &&
+		reflect.DeepEqual(upf.ComponentSpec, v1alpha1.ComponentSpec{})
+}
+
+// toComponent converts the deployment of a network function to its v1alpha1 layout
+func (nf NetworkFunctionSpec) toComponent() v1alpha1.ComponentSpec {
+	return v1alpha1.ComponentSpec{
+		Image:      nf.Image,
+		Replicas:   nf.Replicas,
+		Resources:  nf.Resources,
+		Networks:   nf.Networks,
+		Ports:      nf.Ports,
+		Expose:     nf.Expose,
+		Probes:     nf.Probes,
+		Config:     nf.ConfigFiles,
+		ConfigFrom: nf.ConfigFrom,
+	}
+}
+
+// fromComponent converts the v1alpha1 deployment of a network function
+func fromComponent(component v1alpha1.ComponentSpec) NetworkFunctionSpec {
+	return NetworkFunctionSpec{
+		Image:       component.Image,
+		Replicas:    component.Replicas,
+		Resources:   component.Resources,
+		Networks:    component.Networks,
+		Ports:       component.Ports,
+		Expose:      component.Expose,
+		Probes:      component.Probes,
+		ConfigFiles: component.Config,
+		ConfigFrom:  component.ConfigFrom,
+	}
+}
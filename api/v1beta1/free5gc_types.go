@@ -0,0 +1,269 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// NetworkFunctionSpec defines the deployment of a network function, shared by every
+// network function
+type NetworkFunctionSpec struct {
+	// Image is the container image of the network function, overriding the
+	// image of the free5gc version
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Replicas is the number of replicas to deploy
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources specifies the compute resources of the network function
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Networks are the reference points of the networks attached to the network function,
+	// overriding its default: N2 for the AMF, N4 for the SMF, N3, N4, N6 and N9 for the UPF,
+	// and N2, N3 and NWu for the N3IWF. Reference points without network in the spec are
+	// not attached.
+	// +kubebuilder:validation:items:Enum=N2;N3;N4;N6;N9;NWu
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+	// Ports overrides the ports of the network function by name: sbi for the control
+	// plane network functions, ngap for the AMF, pfcp for the SMF and the UPF, gtpu for
+	// the UPF, ike and nat-t for the N3IWF, and http for the WebUI. The sbi and ngap ports
+	// are rendered into the configuration, unless it sets them; the other ports are fixed
+	// by free5gc and only change the port of the Service.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Ports []v1alpha1.PortSpec `json:"ports,omitempty"`
+	// Expose exposes the Service of the network function outside the cluster, such as
+	// the AMF to the gNBs. ULCL instances inherit the exposure of the UPF.
+	// +optional
+	Expose *v1alpha1.ExposeSpec `json:"expose,omitempty"`
+	// Probes overrides the thresholds of the liveness, readiness and startup probes of
+	// the network function. ULCL instances inherit the probes of the UPF.
+	// +optional
+	Probes *v1alpha1.ProbesSpec `json:"probes,omitempty"`
+	// ConfigFiles are configuration files, keyed by file name, mounted into the
+	// configuration directory of the network function. A file named after a rendered
+	// configuration file is merged with it: the file overrides the operator defaults
+	// and the typed configuration overrides the file.
+	// +optional
+	ConfigFiles map[string]string `json:"configFiles,omitempty"`
+	// ConfigFrom lists configuration files taken from ConfigMaps and Secrets in the
+	// namespace of the Free5GC, mounted into the configuration directory of the network
+	// function. Files from ConfigMaps behave as ConfigFiles entries, while files from
+	// Secrets are mounted as is and cannot be named after a rendered configuration file.
+	// +optional
+	ConfigFrom []v1alpha1.ConfigFileSource `json:"configFrom,omitempty"`
+}
+
+// NRFSpec defines the NRF (Network Repository Function)
+type NRFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into nrfcfg.yaml
+	// +optional
+	Config *v1alpha1.NRFConfig `json:"config,omitempty"`
+}
+
+// AMFSpec defines the AMF (Access and Mobility Management Function)
+type AMFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into amfcfg.yaml
+	// +optional
+	Config *v1alpha1.AMFConfig `json:"config,omitempty"`
+}
+
+// SMFSpec defines the SMF (Session Management Function)
+type SMFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into smfcfg.yaml and uerouting.yaml
+	// +optional
+	Config *v1alpha1.SMFConfig `json:"config,omitempty"`
+}
+
+// UPFSpec defines the UPF (User Plane Function). When the topology has ULCL enabled,
+// it is the base of the ULCL instances instead of being deployed itself.
+type UPFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into upfcfg.yaml
+	// +optional
+	Config *v1alpha1.UPFConfig `json:"config,omitempty"`
+}
+
+// AUSFSpec defines the AUSF (Authentication Server Function)
+type AUSFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into ausfcfg.yaml
+	// +optional
+	Config *v1alpha1.AUSFConfig `json:"config,omitempty"`
+}
+
+// NSSFSpec defines the NSSF (Network Slice Selection Function)
+type NSSFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into nssfcfg.yaml
+	// +optional
+	Config *v1alpha1.NSSFConfig `json:"config,omitempty"`
+}
+
+// PCFSpec defines the PCF (Policy Control Function)
+type PCFSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into pcfcfg.yaml
+	// +optional
+	Config *v1alpha1.PCFConfig `json:"config,omitempty"`
+}
+
+// UDMSpec defines the UDM (Unified Data Management)
+type UDMSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into udmcfg.yaml
+	// +optional
+	Config *v1alpha1.UDMConfig `json:"config,omitempty"`
+}
+
+// UDRSpec defines the UDR (Unified Data Repository)
+type UDRSpec struct {
+	NetworkFunctionSpec `json:",inline"`
+	// Config is rendered into udrcfg.yaml
+	// +optional
+	Config *v1alpha1.UDRConfig `json:"config,omitempty"`
+}
+
+// NetworkFunctionsSpec defines the network functions of the core. A network function
+// is deployed when it is set.
+type NetworkFunctionsSpec struct {
+	// +optional
+	NRF *NRFSpec `json:"nrf,omitempty"`
+	// +optional
+	AMF *AMFSpec `json:"amf,omitempty"`
+	// +optional
+	SMF *SMFSpec `json:"smf,omitempty"`
+	// +optional
+	UPF *UPFSpec `json:"upf,omitempty"`
+	// +optional
+	AUSF *AUSFSpec `json:"ausf,omitempty"`
+	// +optional
+	NSSF *NSSFSpec `json:"nssf,omitempty"`
+	// +optional
+	PCF *PCFSpec `json:"pcf,omitempty"`
+	// +optional
+	UDM *UDMSpec `json:"udm,omitempty"`
+	// +optional
+	UDR *UDRSpec `json:"udr,omitempty"`
+	// N3IWF (Non-3GPP InterWorking Function), configured with configuration files only
+	// +optional
+	N3IWF *NetworkFunctionSpec `json:"n3iwf,omitempty"`
+	// WebUI, configured with configuration files only
+	// +optional
+	WebUI *NetworkFunctionSpec `json:"webui,omitempty"`
+}
+
+// TopologySpec defines how the user plane is laid out
+type TopologySpec struct {
+	// ULCL (Uplink Classifier) topology
+	// +optional
+	ULCL *ULCLSpec `json:"ulcl,omitempty"`
+}
+
+// ULCLSpec defines an uplink classifier topology, in which the first instance is
+// connected to the access network and the remaining instances anchor PDU sessions
+type ULCLSpec struct {
+	// Enable ULCL feature
+	Enabled bool `json:"enabled"`
+	// UPF instances, inheriting the settings of the UPF network function
+	// +optional
+	Instances []UPFInstanceSpec `json:"instances,omitempty"`
+}
+
+// UPFInstanceSpec defines a UPF instance of an uplink classifier topology
+type UPFInstanceSpec struct {
+	// Name of the UPF instance
+	Name                string `json:"name"`
+	NetworkFunctionSpec `json:",inline"`
+	// Config overrides the UPF configuration for this instance
+	// +optional
+	Config *v1alpha1.UPFConfig `json:"config,omitempty"`
+}
+
+// Free5GCSpec defines the desired state of Free5GC
+type Free5GCSpec struct {
+	// Version is the free5gc release to deploy. It selects the default images of
+	// the network functions and the schema of their configuration files.
+	// +kubebuilder:validation:Enum=v3.3.0;v3.4.0
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ImageRegistry is the registry and repository prefix of the default images,
+	// such as registry.example.com/free5gc. Defaults to the free5gc Docker Hub images.
+	// +optional
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// PLMN served by the core, used by every network function unless
+	// overridden in its own configuration
+	// +optional
+	PLMN *v1alpha1.PLMNSpec `json:"plmn,omitempty"`
+
+	// Network slices of the core, used by every network function unless
+	// overridden in its own configuration
+	// +optional
+	Slices []v1alpha1.SliceSpec `json:"slices,omitempty"`
+
+	// MongoDB configuration
+	// +optional
+	MongoDB *v1alpha1.MongoDBSpec `json:"mongodb,omitempty"`
+
+	// Network functions of the core
+	// +optional
+	NetworkFunctions NetworkFunctionsSpec `json:"networkFunctions,omitempty"`
+
+	// Topology of the user plane
+	// +optional
+	Topology *TopologySpec `json:"topology,omitempty"`
+
+	// Network configuration for the Free5GC deployment
+	// +optional
+	Network v1alpha1.NetworkSpec `json:"network,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Free5GC is the Schema for the free5gcs API
+type Free5GC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Free5GCSpec            `json:"spec,omitempty"`
+	Status v1alpha1.Free5GCStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Free5GCList contains a list of Free5GC
+type Free5GCList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Free5GC `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Free5GC{}, &Free5GCList{})
+}
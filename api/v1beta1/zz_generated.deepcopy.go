@@ -0,0 +1,501 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMFSpec) DeepCopyInto(out *AMFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.AMFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMFSpec.
+func (in *AMFSpec) DeepCopy() *AMFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AMFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AUSFSpec) DeepCopyInto(out *AUSFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.AUSFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AUSFSpec.
+func (in *AUSFSpec) DeepCopy() *AUSFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AUSFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Free5GC) DeepCopyInto(out *Free5GC) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Free5GC.
+func (in *Free5GC) DeepCopy() *Free5GC {
+	if in == nil {
+		return nil
+	}
+	out := new(Free5GC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Free5GC) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Free5GCList) DeepCopyInto(out *Free5GCList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Free5GC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Free5GCList.
+func (in *Free5GCList) DeepCopy() *Free5GCList {
+	if in == nil {
+		return nil
+	}
+	out := new(Free5GCList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Free5GCList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Free5GCSpec) DeepCopyInto(out *Free5GCSpec) {
+	*out = *in
+	if in.PLMN != nil {
+		in, out := &in.PLMN, &out.PLMN
+		*out = new(v1alpha1.PLMNSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Slices != nil {
+		in, out := &in.Slices, &out.Slices
+		*out = make([]v1alpha1.SliceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MongoDB != nil {
+		in, out := &in.MongoDB, &out.MongoDB
+		*out = new(v1alpha1.MongoDBSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.NetworkFunctions.DeepCopyInto(&out.NetworkFunctions)
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Network.DeepCopyInto(&out.Network)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Free5GCSpec.
+func (in *Free5GCSpec) DeepCopy() *Free5GCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Free5GCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NRFSpec) DeepCopyInto(out *NRFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.NRFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NRFSpec.
+func (in *NRFSpec) DeepCopy() *NRFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NRFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NSSFSpec) DeepCopyInto(out *NSSFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.NSSFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NSSFSpec.
+func (in *NSSFSpec) DeepCopy() *NSSFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NSSFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkFunctionSpec) DeepCopyInto(out *NetworkFunctionSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1alpha1.PortSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(v1alpha1.ExposeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(v1alpha1.ProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigFiles != nil {
+		in, out := &in.ConfigFiles, &out.ConfigFiles
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = make([]v1alpha1.ConfigFileSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkFunctionSpec.
+func (in *NetworkFunctionSpec) DeepCopy() *NetworkFunctionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkFunctionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkFunctionsSpec) DeepCopyInto(out *NetworkFunctionsSpec) {
+	*out = *in
+	if in.NRF != nil {
+		in, out := &in.NRF, &out.NRF
+		*out = new(NRFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMF != nil {
+		in, out := &in.AMF, &out.AMF
+		*out = new(AMFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMF != nil {
+		in, out := &in.SMF, &out.SMF
+		*out = new(SMFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UPF != nil {
+		in, out := &in.UPF, &out.UPF
+		*out = new(UPFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AUSF != nil {
+		in, out := &in.AUSF, &out.AUSF
+		*out = new(AUSFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NSSF != nil {
+		in, out := &in.NSSF, &out.NSSF
+		*out = new(NSSFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PCF != nil {
+		in, out := &in.PCF, &out.PCF
+		*out = new(PCFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UDM != nil {
+		in, out := &in.UDM, &out.UDM
+		*out = new(UDMSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UDR != nil {
+		in, out := &in.UDR, &out.UDR
+		*out = new(UDRSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.N3IWF != nil {
+		in, out := &in.N3IWF, &out.N3IWF
+		*out = new(NetworkFunctionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebUI != nil {
+		in, out := &in.WebUI, &out.WebUI
+		*out = new(NetworkFunctionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkFunctionsSpec.
+func (in *NetworkFunctionsSpec) DeepCopy() *NetworkFunctionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkFunctionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PCFSpec) DeepCopyInto(out *PCFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.PCFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PCFSpec.
+func (in *PCFSpec) DeepCopy() *PCFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PCFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMFSpec) DeepCopyInto(out *SMFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.SMFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMFSpec.
+func (in *SMFSpec) DeepCopy() *SMFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SMFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
+	*out = *in
+	if in.ULCL != nil {
+		in, out := &in.ULCL, &out.ULCL
+		*out = new(ULCLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpec.
+func (in *TopologySpec) DeepCopy() *TopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDMSpec) DeepCopyInto(out *UDMSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.UDMConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDMSpec.
+func (in *UDMSpec) DeepCopy() *UDMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UDMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDRSpec) DeepCopyInto(out *UDRSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.UDRConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDRSpec.
+func (in *UDRSpec) DeepCopy() *UDRSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UDRSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ULCLSpec) DeepCopyInto(out *ULCLSpec) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]UPFInstanceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ULCLSpec.
+func (in *ULCLSpec) DeepCopy() *ULCLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ULCLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UPFInstanceSpec) DeepCopyInto(out *UPFInstanceSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.UPFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UPFInstanceSpec.
+func (in *UPFInstanceSpec) DeepCopy() *UPFInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UPFInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UPFSpec) DeepCopyInto(out *UPFSpec) {
+	*out = *in
+	in.NetworkFunctionSpec.DeepCopyInto(&out.NetworkFunctionSpec)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha1.UPFConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UPFSpec.
+func (in *UPFSpec) DeepCopy() *UPFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UPFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
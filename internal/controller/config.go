@@ -0,0 +1,1144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+	"github.com/Kyuzial/free5gc-k8s/internal/release"
+)
+
+const (
+	// configMountPath is where free5gc images read their configuration files from
+	configMountPath = "/free5gc/config"
+	// configChecksumAnnotation is the pod template annotation holding the checksum of the
+	// configuration, so that pods are rolled out when their configuration changes
+	configChecksumAnnotation = "core.free5gc.org/config-checksum"
+)
+
+// componentConfig is the configuration mounted into the pods of a component
+type componentConfig struct {
+	// configMap is the name of the ConfigMap mounted at configMountPath
+	configMap string
+	// secretFiles are the files mounted from Secrets next to the ConfigMap files
+	secretFiles []secretFile
+	// checksum is the checksum of the configuration files
+	checksum string
+}
+
+// secretFile is a configuration file mounted from a Secret key
+type secretFile struct {
+	// name is the name of the configuration file
+	name string
+	// secret and key select the content of the file
+	secret string
+	key    string
+	// content is only used to compute the configuration checksum
+	content string
+}
+
+// configMapName returns the name of the ConfigMap holding a component's configuration
+func configMapName(free5gc *corev1alpha1.Free5GC, component string) string {
+	return fmt.Sprintf("%s-%s-config", free5gc.Name, component)
+}
+
+// renderedConfig is a configuration file rendered by the operator. The defaults
+// only apply to the settings missing from the user file with the same name, while
+// the settings, taken from the typed configuration, override the user file.
+type renderedConfig struct {
+	defaults map[string]interface{}
+	settings map[string]interface{}
+	// required are the dotted paths of the settings that must be set, by the
+	// operator, the user file or the typed configuration
+	required []string
+	// secretSettings are set once the file is rendered, which is then written to the
+	// Secret of the component
+	secretSettings []secretSetting
+}
+
+// secretSetting is a setting of a rendered configuration read from a Secret key, so
+// that secrets are neither set in the spec nor written to a ConfigMap
+type secretSetting struct {
+	// path is the path of the setting, made of map keys and list indexes
+	path []interface{}
+	ref  corev1.SecretKeySelector
+}
+
+// Helper function to create or update the ConfigMap holding a component's configuration files.
+// The files provided by the user are written as is, and the rendered configurations are
+// merged with the user file with the same name, if any. Files from Secrets are not copied
+// to the ConfigMap, but are returned to be mounted along with it. Likewise, the rendered
+// files holding secrets, read from Secret keys or merged with a file from a Secret, are
+// written to the Secret of the component rather than to the ConfigMap.
+func (r *Free5GCReconciler) reconcileConfigMap(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, spec *corev1alpha1.ComponentSpec, rendered map[string]renderedConfig) (*componentConfig, error) {
+	log := log.FromContext(ctx)
+
+	files, secretFiles, err := r.resolveConfigFrom(ctx, free5gc, spec.ConfigFrom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve configFrom for %s: %w", component, err)
+	}
+	for name, content := range spec.Config {
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("configuration file %s of %s is set in both config and configFrom", name, component)
+		}
+		files[name] = content
+	}
+	// The files from Secrets named after a rendered file are merged with it
+	secretBases := map[string]string{}
+	var mounted []secretFile
+	for _, file := range secretFiles {
+		if _, ok := files[file.name]; ok {
+			return nil, fmt.Errorf("configuration file %s of %s is defined more than once", file.name, component)
+		}
+		if _, ok := rendered[file.name]; ok {
+			secretBases[file.name] = file.content
+			continue
+		}
+		mounted = append(mounted, file)
+	}
+
+	data := make(map[string]string, len(files)+len(rendered))
+	for name, content := range files {
+		data[name] = content
+	}
+	secretData := map[string]string{}
+	for name, config := range rendered {
+		base, sensitive := secretBases[name]
+		if !sensitive {
+			base = files[name]
+		}
+		content, err := renderConfigFile(base, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s of %s: %w", name, component, err)
+		}
+		for _, setting := range config.secretSettings {
+			value, err := r.secretValue(ctx, free5gc, setting.ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render %s of %s: %w", name, component, err)
+			}
+			if err := setSetting(content, setting.path, value); err != nil {
+				return nil, fmt.Errorf("failed to render %s of %s: %w", name, component, err)
+			}
+			sensitive = true
+		}
+
+		configYaml, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s of %s: %w", name, component, err)
+		}
+		if sensitive {
+			delete(data, name)
+			secretData[name] = string(configYaml)
+		} else {
+			data[name] = string(configYaml)
+		}
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(free5gc, component),
+			Namespace: free5gc.Namespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if err := ctrl.SetControllerReference(free5gc, cm, r.Scheme); err != nil {
+			return err
+		}
+		cm.Labels = componentLabels(free5gc, component)
+		cm.Data = data
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile ConfigMap for %s: %w", component, err)
+	}
+
+	if err := r.reconcileConfigSecret(ctx, free5gc, component, secretData); err != nil {
+		return nil, err
+	}
+	secretFiles = mounted
+	names := make([]string, 0, len(secretData))
+	for name := range secretData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		secretFiles = append(secretFiles, secretFile{
+			name:    name,
+			secret:  configMapName(free5gc, component),
+			key:     name,
+			content: secretData[name],
+		})
+	}
+
+	log.Info("Reconciled ConfigMap", "component", component, "operation", op)
+
+	checksummed := make(map[string]string, len(cm.Data)+len(secretFiles))
+	for name, content := range cm.Data {
+		checksummed[name] = content
+	}
+	for _, file := range secretFiles {
+		checksummed[file.name] = file.content
+	}
+	return &componentConfig{
+		configMap:   cm.Name,
+		secretFiles: secretFiles,
+		checksum:    dataChecksum(checksummed),
+	}, nil
+}
+
+// Helper function to read the configuration files referenced by a component. The files
+// from ConfigMaps are returned by name, while the files from Secrets are returned apart
+// so that their content never ends up in a ConfigMap. Optional references to missing
+// objects or keys are skipped.
+func (r *Free5GCReconciler) resolveConfigFrom(ctx context.Context, free5gc *corev1alpha1.Free5GC, sources []corev1alpha1.ConfigFileSource) (map[string]string, []secretFile, error) {
+	files := map[string]string{}
+	var secretFiles []secretFile
+	for _, source := range sources {
+		switch {
+		case source.ConfigMapKeyRef != nil && source.SecretKeyRef == nil:
+			ref := source.ConfigMapKeyRef
+			cm := &corev1.ConfigMap{}
+			err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: free5gc.Namespace}, cm)
+			if errors.IsNotFound(err) && isOptional(ref.Optional) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get ConfigMap %s: %w", ref.Name, err)
+			}
+			content, ok := cm.Data[ref.Key]
+			if !ok {
+				if isOptional(ref.Optional) {
+					continue
+				}
+				return nil, nil, fmt.Errorf("ConfigMap %s has no key %s", ref.Name, ref.Key)
+			}
+
+			name := configFileName(source, ref.Key)
+			if _, ok := files[name]; ok {
+				return nil, nil, fmt.Errorf("configuration file %s is defined more than once", name)
+			}
+			files[name] = content
+
+		case source.SecretKeyRef != nil && source.ConfigMapKeyRef == nil:
+			ref := source.SecretKeyRef
+			secret := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: free5gc.Namespace}, secret)
+			if errors.IsNotFound(err) && isOptional(ref.Optional) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get Secret %s: %w", ref.Name, err)
+			}
+			content, ok := secret.Data[ref.Key]
+			if !ok {
+				if isOptional(ref.Optional) {
+					continue
+				}
+				return nil, nil, fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+			}
+
+			secretFiles = append(secretFiles, secretFile{
+				name:    configFileName(source, ref.Key),
+				secret:  ref.Name,
+				key:     ref.Key,
+				content: string(content),
+			})
+
+		default:
+			return nil, nil, fmt.Errorf("configFrom entries must set exactly one of configMapKeyRef and secretKeyRef")
+		}
+	}
+
+	for i, file := range secretFiles {
+		for _, other := range secretFiles[:i] {
+			if other.name == file.name {
+				return nil, nil, fmt.Errorf("configuration file %s is defined more than once", file.name)
+			}
+		}
+	}
+	return files, secretFiles, nil
+}
+
+// reconcileConfigSecret creates or updates the Secret holding the rendered files of a
+// component that hold secrets. It shares the name of the ConfigMap of the component, and
+// is deleted once no rendered file holds secrets.
+func (r *Free5GCReconciler) reconcileConfigSecret(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, data map[string]string) error {
+	log := log.FromContext(ctx)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName(free5gc, component),
+			Namespace: free5gc.Namespace,
+		},
+	}
+
+	if len(data) == 0 {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(secret, free5gc) {
+			return nil
+		}
+		if err := r.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete configuration Secret for %s: %w", component, err)
+		}
+		return nil
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if err := ctrl.SetControllerReference(free5gc, secret, r.Scheme); err != nil {
+			return err
+		}
+		secret.Labels = componentLabels(free5gc, component)
+		secret.Data = make(map[string][]byte, len(data))
+		for name, content := range data {
+			secret.Data[name] = []byte(content)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile configuration Secret for %s: %w", component, err)
+	}
+
+	log.Info("Reconciled configuration Secret", "component", component, "operation", op)
+	return nil
+}
+
+// secretValue returns the value of a Secret key, or an empty string when an optional
+// key or Secret is missing
+func (r *Free5GCReconciler) secretValue(ctx context.Context, free5gc *corev1alpha1.Free5GC, ref corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: free5gc.Namespace}, secret)
+	if errors.IsNotFound(err) && isOptional(ref.Optional) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get Secret %s: %w", ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok && !isOptional(ref.Optional) {
+		return "", fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
+
+// setSetting sets the setting at a path of map keys and list indexes of a rendered
+// configuration. The maps and lists leading to the setting must exist.
+func setSetting(content map[string]interface{}, path []interface{}, value string) error {
+	var parent interface{} = content
+	for i, step := range path {
+		last := i == len(path)-1
+		switch step := step.(type) {
+		case string:
+			section, ok := parent.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("setting %v is not in a map", path[:i+1])
+			}
+			if last {
+				section[step] = value
+				return nil
+			}
+			parent = section[step]
+		case int:
+			list, ok := parent.([]interface{})
+			if !ok || step >= len(list) {
+				return fmt.Errorf("setting %v is not in a list", path[:i+1])
+			}
+			if last {
+				list[step] = value
+				return nil
+			}
+			parent = list[step]
+		}
+	}
+	return fmt.Errorf("invalid setting path %v", path)
+}
+
+// configFromReferences returns the ConfigMaps and Secrets referenced by the configFrom
+// entries of every component, as keys of the configFromIndex field index
+func configFromReferences(free5gc *corev1alpha1.Free5GC) []string {
+	specs := []*corev1alpha1.ComponentSpec{free5gc.Spec.N3IWF, free5gc.Spec.WebUI}
+	for _, nf := range networkFunctions(free5gc) {
+		specs = append(specs, nf.spec)
+	}
+	if upf := free5gc.Spec.UPF; upf != nil {
+		specs = append(specs, &upf.ComponentSpec)
+		if upf.ULCL != nil {
+			for i := range upf.ULCL.Instances {
+				specs = append(specs, &upf.ULCL.Instances[i].ComponentSpec)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	var refs []string
+	for _, spec := range specs {
+		if spec == nil {
+			continue
+		}
+		for _, source := range spec.ConfigFrom {
+			var ref string
+			switch {
+			case source.ConfigMapKeyRef != nil:
+				ref = configFromKey("ConfigMap", source.ConfigMapKeyRef.Name)
+			case source.SecretKeyRef != nil:
+				ref = configFromKey("Secret", source.SecretKeyRef.Name)
+			default:
+				continue
+			}
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	// The UDM configuration holds the SUCI private keys of their Secrets
+	if udm := free5gc.Spec.UDM; udm != nil && udm.UDMConfig != nil {
+		for _, profile := range udm.UDMConfig.SUCIProfiles {
+			ref := configFromKey("Secret", profile.PrivateKeySecretRef.Name)
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// configFromKey returns the configFromIndex key of a ConfigMap or Secret
+func configFromKey(kind string, name string) string {
+	return kind + "/" + name
+}
+
+// configFileName returns the name of a configuration file taken from a key
+func configFileName(source corev1alpha1.ConfigFileSource, key string) string {
+	if source.Name != "" {
+		return source.Name
+	}
+	return key
+}
+
+// isOptional reports whether an optional reference flag is set
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// dataChecksum returns a checksum of configuration files keyed by file name
+func dataChecksum(data map[string]string) string {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\x00%s\x00", name, data[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// renderConfigFile merges a YAML file provided by the user over the defaults of a
+// rendered configuration, then merges the settings of the rendered configuration
+// on top. Empty defaults are left out, and settings left empty in the typed
+// configuration keep the value of the file.
+func renderConfigFile(file string, config renderedConfig) (map[string]interface{}, error) {
+	content, err := plainMap(config.defaults)
+	if err != nil {
+		return nil, err
+	}
+	content = pruneEmpty(content)
+
+	base := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(file), &base); err != nil {
+		return nil, err
+	}
+	content = mergeMaps(content, base)
+
+	settings, err := plainMap(config.settings)
+	if err != nil {
+		return nil, err
+	}
+	content = mergeMaps(content, pruneEmpty(settings))
+
+	for _, path := range config.required {
+		if !isSet(content, path) {
+			return nil, fmt.Errorf("%s must be set", path)
+		}
+	}
+	return content, nil
+}
+
+// isSet reports whether the setting at a dotted path of a configuration is set and not empty
+func isSet(content map[string]interface{}, path string) bool {
+	var value interface{} = content
+	for _, key := range strings.Split(path, ".") {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		value = section[key]
+	}
+	return len(pruneEmpty(map[string]interface{}{"value": value})) > 0
+}
+
+// plainMap round-trips a rendered configuration through YAML so that it only
+// holds plain maps and lists
+func plainMap(rendered map[string]interface{}) (map[string]interface{}, error) {
+	raw, err := yaml.Marshal(rendered)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]interface{}{}
+	if err := yaml.Unmarshal(raw, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// pruneEmpty recursively removes the unset values of a configuration: nulls, empty
+// strings, zero numbers and empty maps or lists. Booleans are always kept.
+func pruneEmpty(content map[string]interface{}) map[string]interface{} {
+	for key, value := range content {
+		switch v := value.(type) {
+		case nil:
+			delete(content, key)
+		case string:
+			if v == "" {
+				delete(content, key)
+			}
+		case float64:
+			if v == 0 {
+				delete(content, key)
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				delete(content, key)
+			}
+		case map[string]interface{}:
+			if len(pruneEmpty(v)) == 0 {
+				delete(content, key)
+			}
+		}
+	}
+	return content
+}
+
+// mergeMaps recursively merges overlay into base. Lists are replaced, not merged.
+func mergeMaps(base, overlay map[string]interface{}) map[string]interface{} {
+	for key, value := range overlay {
+		if value == nil {
+			continue
+		}
+		if overlayMap, ok := value.(map[string]interface{}); ok {
+			if baseMap, ok := base[key].(map[string]interface{}); ok {
+				base[key] = mergeMaps(baseMap, overlayMap)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
+
+// defaultSBIConfig returns the SBI configuration used when none is provided. The IPv4
+// binding address is required by every release; IPv6 cores also bind the IPv6 wildcard address.
+func defaultSBIConfig(free5gc *corev1alpha1.Free5GC, component string) *corev1alpha1.SBIConfig {
+	sbi := &corev1alpha1.SBIConfig{
+		Scheme:       "http",
+		RegisterIPv4: fmt.Sprintf("%s-%s", free5gc.Name, component),
+		BindingIPv4:  "0.0.0.0",
+		Port:         componentPortNumber(free5gc, component, "sbi", componentSpec(free5gc, component)),
+	}
+	if hasIPFamily(free5gc, corev1.IPv6Protocol) {
+		sbi.BindingIP = "::"
+	}
+	return sbi
+}
+
+// defaultNRFURI returns the URI of the NRF deployed by this Free5GC instance
+func defaultNRFURI(free5gc *corev1alpha1.Free5GC) string {
+	return fmt.Sprintf("http://%s-nrf:%d", free5gc.Name, componentPortNumber(free5gc, "nrf", "sbi", componentSpec(free5gc, "nrf")))
+}
+
+// sbiSection renders an SBI configuration in the free5gc layout
+func sbiSection(sbi *corev1alpha1.SBIConfig) map[string]interface{} {
+	section := map[string]interface{}{
+		"scheme":       sbi.Scheme,
+		"registerIPv4": sbi.RegisterIPv4,
+		"bindingIPv4":  sbi.BindingIPv4,
+		"port":         sbi.Port,
+	}
+	if sbi.RegisterIP != "" {
+		section["registerIP"] = sbi.RegisterIP
+	}
+	if sbi.BindingIP != "" {
+		section["bindingIP"] = sbi.BindingIP
+	}
+	return section
+}
+
+// buildAMFConfig renders the amfcfg.yaml content for the AMF
+func buildAMFConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.AMFConfig{}
+	if free5gc.Spec.AMF.AMFConfig != nil {
+		config = free5gc.Spec.AMF.AMFConfig
+	}
+
+	// Timers and network name default to the values of the free5gc sample configuration
+	defaultNetworkName := corev1alpha1.NetworkNameConfig{Full: "free5GC", Short: "free"}
+	defaultTimer := &corev1alpha1.NASTimerConfig{Enable: true, ExpireTime: "6s", MaxRetryTimes: 4}
+	defaults := map[string]interface{}{
+		"amfName":         "AMF",
+		"ngapIpList":      []string{wildcardAddr(free5gc)},
+		"ngapPort":        componentPortNumber(free5gc, "amf", "ngap", &free5gc.Spec.AMF.ComponentSpec),
+		"sbi":             sbiSection(defaultSBIConfig(free5gc, "amf")),
+		"serviceNameList": []string{"namf-comm", "namf-evts", "namf-mt", "namf-loc", "namf-oam"},
+		"nrfUri":          defaultNRFURI(free5gc),
+		"security": &corev1alpha1.SecurityConfig{
+			IntegrityOrder: []string{"NIA2"},
+			CipheringOrder: []string{"NEA0"},
+		},
+		"networkName":            &defaultNetworkName,
+		"t3502Value":             720,
+		"t3512Value":             3600,
+		"non3gppDeregTimerValue": 3240,
+		"t3513":                  defaultTimer,
+		"t3522":                  defaultTimer,
+		"t3550":                  defaultTimer,
+		"t3560":                  defaultTimer,
+		"t3565":                  defaultTimer,
+		"t3570":                  defaultTimer,
+		"supportDnnList":         sliceDNNs(free5gc),
+	}
+	if plmn := free5gc.Spec.PLMN; plmn != nil {
+		defaults["servedGuamiList"] = servedGUAMIs(plmn)
+		defaults["supportTaiList"] = plmn.TAIs
+		defaults["plmnSupportList"] = plmnSupport(free5gc)
+		defaults["networkName"] = networkName(plmn, defaultNetworkName)
+	}
+
+	settings := map[string]interface{}{
+		"amfName":         config.AMFName,
+		"ngapIpList":      config.NGAPIPList,
+		"ngapPort":        config.NGAPPort,
+		"sbi":             config.SBI,
+		"serviceNameList": config.ServiceNameList,
+		"servedGuamiList": config.ServedGUAMIList,
+		"supportTaiList":  config.SupportTAIList,
+		"plmnSupportList": config.PLMNSupportList,
+		"supportDnnList":  config.SupportDNNList,
+		"nrfUri":          config.NRFURI,
+		"security":        config.Security,
+		"networkName":     config.NetworkName,
+	}
+
+	// NAS timers are flattened into the configuration section
+	if timers := config.NASTimers; timers != nil {
+		settings["t3502Value"] = timers.T3502Value
+		settings["t3512Value"] = timers.T3512Value
+		settings["non3gppDeregTimerValue"] = timers.Non3gppDeregistrationTimerValue
+		settings["t3513"] = timers.T3513
+		settings["t3522"] = timers.T3522
+		settings["t3550"] = timers.T3550
+		settings["t3560"] = timers.T3560
+		settings["t3565"] = timers.T3565
+		settings["t3570"] = timers.T3570
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "amfcfg.yaml"),
+				"description": "AMF initial local configuration",
+			},
+			"configuration": defaults,
+		},
+		settings: map[string]interface{}{
+			"configuration": settings,
+		},
+		required: []string{
+			"configuration.servedGuamiList",
+			"configuration.supportTaiList",
+			"configuration.plmnSupportList",
+			"configuration.supportDnnList",
+		},
+	}
+}
+
+// buildSMFConfig renders the smfcfg.yaml content for the SMF
+func buildSMFConfig(free5gc *corev1alpha1.Free5GC) (renderedConfig, error) {
+	config := &corev1alpha1.SMFConfig{}
+	if free5gc.Spec.SMF.SMFConfig != nil {
+		config = free5gc.Spec.SMF.SMFConfig
+	}
+
+	pfcp := corev1alpha1.SMFPFCPConfig{
+		NodeID:            fmt.Sprintf("%s-smf", free5gc.Name),
+		ListenAddr:        wildcardAddr(free5gc),
+		HeartbeatInterval: "5s",
+	}
+	if config.PFCP != nil && config.PFCP.NodeID != "" {
+		pfcp.NodeID = config.PFCP.NodeID
+	}
+	pfcp.ExternalAddr = pfcp.NodeID
+	// The SMF listens on the address allocated to it on the N4 network, if any
+	if addr := allocatedAddress(free5gc, "smf", "N4"); addr != "" {
+		pfcp.ListenAddr = addr
+		pfcp.ExternalAddr = addr
+	}
+
+	// The user plane is derived from the slices of the core unless set explicitly
+	effective := *config
+	if len(effective.SNSSAIInfos) == 0 {
+		effective.SNSSAIInfos = smfSNSSAIInfos(free5gc)
+	}
+	if len(effective.UEIPPools) == 0 {
+		effective.UEIPPools = smfUEIPPools(free5gc)
+	}
+	userplane, err := userplaneInformation(free5gc, &effective)
+	if err != nil {
+		return renderedConfig{}, err
+	}
+
+	settings := map[string]interface{}{
+		"smfName":              config.SMFName,
+		"sbi":                  config.SBI,
+		"serviceNameList":      config.ServiceNameList,
+		"snssaiInfos":          config.SNSSAIInfos,
+		"plmnList":             config.PLMNList,
+		"pfcp":                 config.PFCP,
+		"userplaneInformation": userplane,
+		"nrfUri":               config.NRFURI,
+		"t3591":                config.T3591,
+		"t3592":                config.T3592,
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "smfcfg.yaml"),
+				"description": "SMF initial local configuration",
+			},
+			"configuration": map[string]interface{}{
+				"smfName":         "SMF",
+				"sbi":             sbiSection(defaultSBIConfig(free5gc, "smf")),
+				"serviceNameList": []string{"nsmf-pdusession", "nsmf-event-exposure", "nsmf-oam"},
+				"pfcp":            pfcp,
+				"nrfUri":          defaultNRFURI(free5gc),
+				"plmnList":        plmnIDs(free5gc),
+				"snssaiInfos":     smfSNSSAIInfos(free5gc),
+			},
+		},
+		settings: map[string]interface{}{
+			"configuration": settings,
+		},
+	}, nil
+}
+
+// buildUERoutingConfig renders the uerouting.yaml content of the SMF. It is always
+// rendered, as mounting the configuration directory hides the file of the image.
+func buildUERoutingConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.SMFConfig{}
+	if free5gc.Spec.SMF.SMFConfig != nil {
+		config = free5gc.Spec.SMF.SMFConfig
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "uerouting.yaml"),
+				"description": "Routing information for UE",
+			},
+		},
+		settings: map[string]interface{}{
+			"ueRoutingInfo": ueRoutingInfo(free5gc, config),
+		},
+	}
+}
+
+// renderSMFConfig renders the configuration files of the SMF
+func renderSMFConfig(free5gc *corev1alpha1.Free5GC) (map[string]renderedConfig, error) {
+	smfcfg, err := buildSMFConfig(free5gc)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]renderedConfig{
+		"smfcfg.yaml":    smfcfg,
+		"uerouting.yaml": buildUERoutingConfig(free5gc),
+	}, nil
+}
+
+// buildUPFConfig renders the upfcfg.yaml content for a standard UPF or a ULCL instance
+func buildUPFConfig(free5gc *corev1alpha1.Free5GC, instance *corev1alpha1.UPFInstance, uplink bool) renderedConfig {
+	upfFile := func(config corev1alpha1.UPFConfig) map[string]interface{} {
+		gtpu := map[string]interface{}{}
+		if config.GTPU != nil {
+			gtpu["forwarder"] = config.GTPU.Forwarder
+			gtpu["ifList"] = config.GTPU.IfList
+		}
+		return map[string]interface{}{
+			"pfcp":    config.PFCP,
+			"gtpu":    gtpu,
+			"dnnList": config.DNNList,
+		}
+	}
+
+	defaults := upfFile(upfConfig(free5gc, instance, uplink))
+	defaults["version"] = configVersion(free5gc, "upfcfg.yaml")
+	defaults["description"] = "UPF initial local configuration"
+
+	return renderedConfig{
+		defaults: defaults,
+		settings: upfFile(upfSettings(free5gc, instance)),
+	}
+}
+
+// mountConfig mounts the configuration of a component into the first container of a
+// pod template, and records its checksum in the template annotations
+func mountConfig(template *corev1.PodTemplateSpec, config *componentConfig) {
+	volume := corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: config.configMap,
+			},
+		},
+	}
+	// Files from Secrets are projected into the same directory as the ConfigMap
+	if len(config.secretFiles) > 0 {
+		sources := []corev1.VolumeProjection{{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: config.configMap,
+				},
+			},
+		}}
+		for _, file := range config.secretFiles {
+			sources = append(sources, corev1.VolumeProjection{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: file.secret,
+					},
+					Items: []corev1.KeyToPath{{Key: file.key, Path: file.name}},
+				},
+			})
+		}
+		volume = corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		}
+	}
+
+	podSpec := &template.Spec
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "config",
+		MountPath: configMountPath,
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         "config",
+		VolumeSource: volume,
+	})
+
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[configChecksumAnnotation] = config.checksum
+}
+
+// configVersion returns the schema version of a configuration file in the free5gc
+// version of the spec, which is checked before reconciling the components
+func configVersion(free5gc *corev1alpha1.Free5GC, file string) string {
+	r, err := release.Lookup(free5gc.Spec.Version)
+	if err != nil {
+		return ""
+	}
+	return r.ConfigVersions[file]
+}
+
+// defaultMongoDBConfig returns the MongoDB settings pointing to the external MongoDB
+// when one is configured and to the managed one otherwise. The URL is left empty when
+// the spec defines no MongoDB, in which case it must be set in the configuration.
+func defaultMongoDBConfig(free5gc *corev1alpha1.Free5GC) *corev1alpha1.MongoDBConfig {
+	config := &corev1alpha1.MongoDBConfig{Name: "free5gc"}
+
+	mongodb := free5gc.Spec.MongoDB
+	switch {
+	case mongodb == nil:
+	case mongodb.External:
+		config.URL = mongodb.URI
+	default:
+		config.URL = fmt.Sprintf("mongodb://%s-mongodb:27017", free5gc.Name)
+	}
+	return config
+}
+
+// buildNRFConfig renders the nrfcfg.yaml content for the NRF
+func buildNRFConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.NRFConfig{}
+	if free5gc.Spec.NRF.NRFConfig != nil {
+		config = free5gc.Spec.NRF.NRFConfig
+	}
+
+	// The NRF takes its MongoDB settings as top-level keys
+	mongodb := defaultMongoDBConfig(free5gc)
+	defaults := map[string]interface{}{
+		"MongoDBName":     mongodb.Name,
+		"MongoDBUrl":      mongodb.URL,
+		"sbi":             sbiSection(defaultSBIConfig(free5gc, "nrf")),
+		"DefaultPlmnId":   corev1alpha1.PLMNID{MCC: "208", MNC: "93"},
+		"serviceNameList": []string{"nnrf-nfm", "nnrf-disc"},
+	}
+	if plmnID := homePLMNID(free5gc); plmnID != nil {
+		defaults["DefaultPlmnId"] = plmnID
+	}
+	settings := map[string]interface{}{
+		"sbi":             config.SBI,
+		"DefaultPlmnId":   config.DefaultPLMNID,
+		"serviceNameList": config.ServiceNameList,
+	}
+	if config.MongoDB != nil {
+		settings["MongoDBName"] = config.MongoDB.Name
+		settings["MongoDBUrl"] = config.MongoDB.URL
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "nrfcfg.yaml"),
+				"description": "NRF initial local configuration",
+			},
+			"configuration": defaults,
+		},
+		settings: map[string]interface{}{
+			"configuration": settings,
+		},
+		required: []string{"configuration.MongoDBUrl"},
+	}
+}
+
+// buildAUSFConfig renders the ausfcfg.yaml content for the AUSF
+func buildAUSFConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.AUSFConfig{}
+	if free5gc.Spec.AUSF.AUSFConfig != nil {
+		config = free5gc.Spec.AUSF.AUSFConfig
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "ausfcfg.yaml"),
+				"description": "AUSF initial local configuration",
+			},
+			"configuration": map[string]interface{}{
+				"sbi":                  sbiSection(defaultSBIConfig(free5gc, "ausf")),
+				"serviceNameList":      []string{"nausf-auth"},
+				"nrfUri":               defaultNRFURI(free5gc),
+				"plmnSupportList":      plmnIDs(free5gc),
+				"groupId":              "ausfGroup001",
+				"eapAkaSupiImsiPrefix": false,
+			},
+		},
+		settings: map[string]interface{}{
+			"configuration": map[string]interface{}{
+				"sbi":                  config.SBI,
+				"serviceNameList":      config.ServiceNameList,
+				"nrfUri":               config.NRFURI,
+				"plmnSupportList":      config.PLMNSupportList,
+				"groupId":              config.GroupID,
+				"eapAkaSupiImsiPrefix": config.EAPAKASupiImsiPrefix,
+			},
+		},
+	}
+}
+
+// buildUDMConfig renders the udmcfg.yaml content for the UDM
+func buildUDMConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.UDMConfig{}
+	if free5gc.Spec.UDM.UDMConfig != nil {
+		config = free5gc.Spec.UDM.UDMConfig
+	}
+
+	// The UDM expects the SUCI profiles with capitalized keys. The private keys are read
+	// from their Secrets once the file is rendered.
+	suciProfiles := make([]map[string]interface{}, 0, len(config.SUCIProfiles))
+	var secretSettings []secretSetting
+	for i, profile := range config.SUCIProfiles {
+		suciProfiles = append(suciProfiles, map[string]interface{}{
+			"ProtectionScheme": profile.ProtectionScheme,
+			"PublicKey":        profile.PublicKey,
+		})
+		secretSettings = append(secretSettings, secretSetting{
+			path: []interface{}{"configuration", "SuciProfile", i, "PrivateKey"},
+			ref:  profile.PrivateKeySecretRef,
+		})
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "udmcfg.yaml"),
+				"description": "UDM initial local configuration",
+			},
+			"configuration": map[string]interface{}{
+				"sbi":             sbiSection(defaultSBIConfig(free5gc, "udm")),
+				"serviceNameList": []string{"nudm-sdm", "nudm-uecm", "nudm-ueau", "nudm-ee", "nudm-pp"},
+				"nrfUri":          defaultNRFURI(free5gc),
+			},
+		},
+		settings: map[string]interface{}{
+			"configuration": map[string]interface{}{
+				"sbi":             config.SBI,
+				"serviceNameList": config.ServiceNameList,
+				"nrfUri":          config.NRFURI,
+				"SuciProfile":     suciProfiles,
+			},
+		},
+		secretSettings: secretSettings,
+	}
+}
+
+// buildUDRConfig renders the udrcfg.yaml content for the UDR
+func buildUDRConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.UDRConfig{}
+	if free5gc.Spec.UDR.UDRConfig != nil {
+		config = free5gc.Spec.UDR.UDRConfig
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "udrcfg.yaml"),
+				"description": "UDR initial local configuration",
+			},
+			"configuration": map[string]interface{}{
+				"sbi":     sbiSection(defaultSBIConfig(free5gc, "udr")),
+				"mongodb": defaultMongoDBConfig(free5gc),
+				"nrfUri":  defaultNRFURI(free5gc),
+			},
+		},
+		settings: map[string]interface{}{
+			"configuration": map[string]interface{}{
+				"sbi":     config.SBI,
+				"mongodb": config.MongoDB,
+				"nrfUri":  config.NRFURI,
+			},
+		},
+		required: []string{"configuration.mongodb.url"},
+	}
+}
+
+// buildPCFConfig renders the pcfcfg.yaml content for the PCF
+func buildPCFConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.PCFConfig{}
+	if free5gc.Spec.PCF.PCFConfig != nil {
+		config = free5gc.Spec.PCF.PCFConfig
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "pcfcfg.yaml"),
+				"description": "PCF initial local configuration",
+			},
+			"configuration": map[string]interface{}{
+				"pcfName":         "PCF",
+				"sbi":             sbiSection(defaultSBIConfig(free5gc, "pcf")),
+				"timeFormat":      "2019-01-02 15:04:05",
+				"defaultBdtRefId": "BdtPolicyId-",
+				"nrfUri":          defaultNRFURI(free5gc),
+				"serviceList": []corev1alpha1.PCFServiceConfig{
+					{ServiceName: "npcf-am-policy-control"},
+					{ServiceName: "npcf-smpolicycontrol", SuppFeat: "3fff"},
+					{ServiceName: "npcf-bdtpolicycontrol"},
+					{ServiceName: "npcf-policyauthorization", SuppFeat: "3"},
+					{ServiceName: "npcf-eventexposure"},
+					{ServiceName: "npcf-ue-policy-control"},
+				},
+				"mongodb": defaultMongoDBConfig(free5gc),
+			},
+		},
+		settings: map[string]interface{}{
+			"configuration": map[string]interface{}{
+				"pcfName":     config.PCFName,
+				"sbi":         config.SBI,
+				"nrfUri":      config.NRFURI,
+				"serviceList": config.ServiceList,
+				"mongodb":     config.MongoDB,
+			},
+		},
+		required: []string{"configuration.mongodb.url"},
+	}
+}
+
+// buildNSSFConfig renders the nssfcfg.yaml content for the NSSF
+func buildNSSFConfig(free5gc *corev1alpha1.Free5GC) renderedConfig {
+	config := &corev1alpha1.NSSFConfig{}
+	if free5gc.Spec.NSSF.NSSFConfig != nil {
+		config = free5gc.Spec.NSSF.NSSFConfig
+	}
+
+	// Network slice instances are registered in the NRF used by the NSSF
+	nrfURI := defaultNRFURI(free5gc)
+	if config.NRFURI != "" {
+		nrfURI = config.NRFURI
+	}
+
+	return renderedConfig{
+		defaults: map[string]interface{}{
+			"info": map[string]interface{}{
+				"version":     configVersion(free5gc, "nssfcfg.yaml"),
+				"description": "NSSF initial local configuration",
+			},
+			"configuration": map[string]interface{}{
+				"nssfName":                 "NSSF",
+				"sbi":                      sbiSection(defaultSBIConfig(free5gc, "nssf")),
+				"serviceNameList":          []string{"nnssf-nsselection", "nnssf-nssaiavailability"},
+				"nrfUri":                   nrfURI,
+				"supportedPlmnList":        plmnIDs(free5gc),
+				"taList":                   nssfTAList(free5gc),
+				"nsiList":                  nssfNSIList(free5gc, nrfURI),
+				"supportedNssaiInPlmnList": nssfSupportedNSSAIs(free5gc),
+			},
+		},
+		settings: map[string]interface{}{
+			"configuration": map[string]interface{}{
+				"sbi":             config.SBI,
+				"serviceNameList": config.ServiceNameList,
+				"nrfUri":          config.NRFURI,
+				"nsiList":         config.NSIList,
+			},
+		},
+	}
+}
@@ -0,0 +1,742 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Configuration rendering", func() {
+	plmnID := corev1alpha1.PLMNID{MCC: "208", MNC: "93"}
+
+	Context("When merging a rendered configuration into a user file", func() {
+		free5gc := &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resource",
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.Free5GCSpec{
+				AMF: &corev1alpha1.AMFSpec{
+					AMFConfig: &corev1alpha1.AMFConfig{
+						ServedGUAMIList: []corev1alpha1.GUAMIConfig{{PLMNID: plmnID, AMFID: "cafe00"}},
+						SupportTAIList:  []corev1alpha1.TAIConfig{{PLMNID: plmnID, TAC: "000001"}},
+						PLMNSupportList: []corev1alpha1.PLMNSupportConfig{{PLMNID: plmnID}},
+						SupportDNNList:  []string{"internet"},
+					},
+				},
+			},
+		}
+
+		It("should keep the settings that are not rendered", func() {
+			file := "configuration:\n  locality: area1\n  sbi:\n    tls:\n      pem: cert/amf.pem\n"
+
+			merged, err := renderConfigFile(file, buildAMFConfig(free5gc))
+			Expect(err).NotTo(HaveOccurred())
+
+			configuration := merged["configuration"].(map[string]interface{})
+			Expect(configuration).To(HaveKeyWithValue("locality", "area1"))
+			Expect(configuration["sbi"]).To(HaveKey("tls"))
+			Expect(configuration["sbi"]).To(HaveKeyWithValue("registerIPv4", "test-resource-amf"))
+		})
+
+		It("should keep the file over the operator defaults", func() {
+			file := "configuration:\n  nrfUri: http://ext-nrf:8000\n  sbi:\n    port: 8000\n"
+
+			merged, err := renderConfigFile(file, buildAMFConfig(free5gc))
+			Expect(err).NotTo(HaveOccurred())
+
+			configuration := merged["configuration"].(map[string]interface{})
+			Expect(configuration).To(HaveKeyWithValue("nrfUri", "http://ext-nrf:8000"))
+			Expect(configuration["sbi"]).To(HaveKeyWithValue("port", BeNumerically("==", 8000)))
+			Expect(configuration["sbi"]).To(HaveKeyWithValue("scheme", "http"))
+		})
+
+		It("should let typed settings take precedence", func() {
+			file := "configuration:\n  supportDnnList:\n    - ims\n  supportTaiList:\n    - tac: \"000002\"\n"
+
+			merged, err := renderConfigFile(file, buildAMFConfig(free5gc))
+			Expect(err).NotTo(HaveOccurred())
+
+			configuration := merged["configuration"].(map[string]interface{})
+			Expect(configuration["supportDnnList"]).To(ConsistOf("internet"))
+			Expect(configuration["supportTaiList"]).To(HaveLen(1))
+		})
+
+		It("should reject files that are not valid YAML", func() {
+			_, err := renderConfigFile("configuration: [", buildAMFConfig(free5gc))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When rendering the AMF configuration", func() {
+		newFree5GC := func(config *corev1alpha1.AMFConfig) *corev1alpha1.Free5GC {
+			return &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-resource",
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.Free5GCSpec{
+					AMF: &corev1alpha1.AMFSpec{AMFConfig: config},
+				},
+			}
+		}
+
+		amfConfig := func() *corev1alpha1.AMFConfig {
+			return &corev1alpha1.AMFConfig{
+				ServedGUAMIList: []corev1alpha1.GUAMIConfig{{PLMNID: plmnID, AMFID: "cafe00"}},
+				SupportTAIList:  []corev1alpha1.TAIConfig{{PLMNID: plmnID, TAC: "000001"}},
+				PLMNSupportList: []corev1alpha1.PLMNSupportConfig{{
+					PLMNID:     plmnID,
+					SNSSAIList: []corev1alpha1.SNSSAIConfig{{SST: 1, SD: "010203"}},
+				}},
+				SupportDNNList: []string{"internet"},
+			}
+		}
+
+		It("should render the free5gc defaults", func() {
+			content, err := renderConfigFile("", buildAMFConfig(newFree5GC(amfConfig())))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(content["info"]).To(HaveKeyWithValue("version", "1.0.9"))
+			configuration := content["configuration"].(map[string]interface{})
+			Expect(configuration).To(HaveKeyWithValue("ngapPort", BeNumerically("==", 38412)))
+			Expect(configuration).To(HaveKeyWithValue("nrfUri", "http://test-resource-nrf:8000"))
+			Expect(configuration["networkName"]).To(HaveKeyWithValue("full", "free5GC"))
+			Expect(configuration).To(HaveKeyWithValue("t3502Value", BeNumerically("==", 720)))
+			Expect(configuration).To(HaveKeyWithValue("non3gppDeregTimerValue", BeNumerically("==", 3240)))
+			for _, timer := range []string{"t3513", "t3522", "t3550", "t3560", "t3565", "t3570"} {
+				Expect(configuration[timer]).To(HaveKeyWithValue("enable", true))
+				Expect(configuration[timer]).To(HaveKeyWithValue("expireTime", "6s"))
+			}
+			Expect(configuration["servedGuamiList"]).To(ConsistOf(HaveKeyWithValue("amfId", "cafe00")))
+			Expect(configuration["plmnSupportList"]).To(ConsistOf(HaveKeyWithValue("snssaiList", ConsistOf(
+				HaveKeyWithValue("sd", "010203"),
+			))))
+		})
+
+		It("should flatten the NAS timers into the configuration", func() {
+			config := amfConfig()
+			config.NASTimers = &corev1alpha1.NASTimersConfig{
+				T3512Value:                      1800,
+				Non3gppDeregistrationTimerValue: 1600,
+				T3513:                           &corev1alpha1.NASTimerConfig{Enable: false},
+			}
+
+			content, err := renderConfigFile("", buildAMFConfig(newFree5GC(config)))
+			Expect(err).NotTo(HaveOccurred())
+
+			configuration := content["configuration"].(map[string]interface{})
+			Expect(configuration).NotTo(HaveKey("nasTimers"))
+			Expect(configuration).NotTo(HaveKey("non3gppDeregistrationTimerValue"))
+			Expect(configuration).To(HaveKeyWithValue("t3512Value", BeNumerically("==", 1800)))
+			Expect(configuration).To(HaveKeyWithValue("t3502Value", BeNumerically("==", 720)))
+			Expect(configuration).To(HaveKeyWithValue("non3gppDeregTimerValue", BeNumerically("==", 1600)))
+			Expect(configuration["t3513"]).To(HaveKeyWithValue("enable", false))
+			Expect(configuration["t3513"]).To(HaveKeyWithValue("maxRetryTimes", BeNumerically("==", 4)))
+		})
+
+		It("should require the served GUAMIs, TAIs, slices and DNNs", func() {
+			_, err := renderConfigFile("", buildAMFConfig(newFree5GC(nil)))
+			Expect(err).To(MatchError(ContainSubstring("configuration.servedGuamiList")))
+
+			config := amfConfig()
+			config.SupportDNNList = nil
+			_, err = renderConfigFile("", buildAMFConfig(newFree5GC(config)))
+			Expect(err).To(MatchError(ContainSubstring("configuration.supportDnnList")))
+
+			file := "configuration:\n  supportDnnList:\n    - internet\n"
+			_, err = renderConfigFile(file, buildAMFConfig(newFree5GC(config)))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When rendering the NRF, AUSF, UDM, UDR and PCF configurations", func() {
+		newFree5GC := func() *corev1alpha1.Free5GC {
+			return &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-resource",
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.Free5GCSpec{
+					MongoDB: &corev1alpha1.MongoDBSpec{Image: "mongo:4.4"},
+					NRF:     &corev1alpha1.NRFSpec{},
+					AUSF:    &corev1alpha1.AUSFSpec{},
+					UDM:     &corev1alpha1.UDMSpec{},
+					UDR:     &corev1alpha1.UDRSpec{},
+					PCF:     &corev1alpha1.PCFSpec{},
+				},
+			}
+		}
+
+		render := func(config renderedConfig) map[string]interface{} {
+			content, err := renderConfigFile("", config)
+			Expect(err).NotTo(HaveOccurred())
+			return content["configuration"].(map[string]interface{})
+		}
+
+		It("should point the NRF to the managed MongoDB", func() {
+			configuration := render(buildNRFConfig(newFree5GC()))
+			Expect(configuration).To(HaveKeyWithValue("MongoDBName", "free5gc"))
+			Expect(configuration).To(HaveKeyWithValue("MongoDBUrl", "mongodb://test-resource-mongodb:27017"))
+			Expect(configuration).To(HaveKeyWithValue("serviceNameList", ConsistOf("nnrf-nfm", "nnrf-disc")))
+			Expect(configuration["sbi"]).To(HaveKeyWithValue("registerIPv4", "test-resource-nrf"))
+		})
+
+		It("should point the UDR and the PCF to the external MongoDB", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.MongoDB = &corev1alpha1.MongoDBSpec{External: true, URI: "mongodb://mongo.db:27017"}
+
+			Expect(render(buildUDRConfig(free5gc))["mongodb"]).To(HaveKeyWithValue("url", "mongodb://mongo.db:27017"))
+			Expect(render(buildPCFConfig(free5gc))["mongodb"]).To(HaveKeyWithValue("url", "mongodb://mongo.db:27017"))
+		})
+
+		It("should require a MongoDB URL when the spec defines no MongoDB", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.MongoDB = nil
+
+			_, err := renderConfigFile("", buildUDRConfig(free5gc))
+			Expect(err).To(MatchError(ContainSubstring("configuration.mongodb.url")))
+
+			free5gc.Spec.UDR.UDRConfig = &corev1alpha1.UDRConfig{
+				MongoDB: &corev1alpha1.MongoDBConfig{Name: "free5gc", URL: "mongodb://mongo.db:27017"},
+			}
+			Expect(render(buildUDRConfig(free5gc))["mongodb"]).To(HaveKeyWithValue("url", "mongodb://mongo.db:27017"))
+		})
+
+		It("should render the AUSF settings", func() {
+			free5gc := newFree5GC()
+			configuration := render(buildAUSFConfig(free5gc))
+			Expect(configuration).To(HaveKeyWithValue("groupId", "ausfGroup001"))
+			Expect(configuration).To(HaveKeyWithValue("eapAkaSupiImsiPrefix", false))
+			Expect(configuration).To(HaveKeyWithValue("nrfUri", "http://test-resource-nrf:8000"))
+
+			prefix := true
+			free5gc.Spec.AUSF.AUSFConfig = &corev1alpha1.AUSFConfig{
+				PLMNSupportList:      []corev1alpha1.PLMNID{{MCC: "208", MNC: "93"}},
+				EAPAKASupiImsiPrefix: &prefix,
+			}
+			configuration = render(buildAUSFConfig(free5gc))
+			Expect(configuration).To(HaveKeyWithValue("eapAkaSupiImsiPrefix", true))
+			Expect(configuration["plmnSupportList"]).To(ConsistOf(HaveKeyWithValue("mcc", "208")))
+		})
+
+		It("should render the UDM SUCI profiles into a Secret with their private keys", func() {
+			free5gc := newFree5GC()
+			free5gc.UID = "free5gc-uid"
+			free5gc.Spec.UDM.UDMConfig = &corev1alpha1.UDMConfig{
+				SUCIProfiles: []corev1alpha1.SUCIProfileConfig{{
+					ProtectionScheme: 1,
+					PrivateKeySecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "suci-keys"},
+						Key:                  "profile-a",
+					},
+					PublicKey: "5a8d",
+				}},
+			}
+
+			rendered := buildUDMConfig(free5gc)
+			Expect(render(rendered)["SuciProfile"]).To(ConsistOf(And(
+				HaveKeyWithValue("ProtectionScheme", BeNumerically("==", 1)),
+				HaveKeyWithValue("PublicKey", "5a8d"),
+				Not(HaveKey("PrivateKey")),
+			)))
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "suci-keys", Namespace: "default"},
+					Data:       map[string][]byte{"profile-a": []byte("c53c")},
+				}).Build(),
+				Scheme: scheme,
+			}
+			config, err := reconciler.reconcileConfigMap(context.Background(), free5gc, "udm", &free5gc.Spec.UDM.ComponentSpec,
+				map[string]renderedConfig{"udmcfg.yaml": rendered})
+			Expect(err).NotTo(HaveOccurred())
+
+			key := types.NamespacedName{Name: "test-resource-udm-config", Namespace: "default"}
+			cm := &corev1.ConfigMap{}
+			Expect(reconciler.Get(context.Background(), key, cm)).To(Succeed())
+			Expect(cm.Data).NotTo(HaveKey("udmcfg.yaml"))
+			secret := &corev1.Secret{}
+			Expect(reconciler.Get(context.Background(), key, secret)).To(Succeed())
+			Expect(string(secret.Data["udmcfg.yaml"])).To(ContainSubstring("PrivateKey: c53c"))
+			Expect(config.secretFiles).To(ConsistOf(secretFile{
+				name: "udmcfg.yaml", secret: "test-resource-udm-config", key: "udmcfg.yaml", content: string(secret.Data["udmcfg.yaml"]),
+			}))
+			Expect(configFromReferences(free5gc)).To(ContainElement("Secret/suci-keys"))
+		})
+
+		It("should merge a rendered file with a file from a Secret into a Secret", func() {
+			free5gc := newFree5GC()
+			free5gc.UID = "free5gc-uid"
+			free5gc.Spec.UDM.ConfigFrom = []corev1alpha1.ConfigFileSource{{
+				Name: "udmcfg.yaml",
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "udm-files"},
+					Key:                  "udmcfg.yaml",
+				},
+			}}
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "udm-files", Namespace: "default"},
+					Data:       map[string][]byte{"udmcfg.yaml": []byte("configuration:\n  SuciProfile:\n  - PrivateKey: c53c\n")},
+				}).Build(),
+				Scheme: scheme,
+			}
+			config, err := reconciler.reconcileConfigMap(context.Background(), free5gc, "udm", &free5gc.Spec.UDM.ComponentSpec,
+				map[string]renderedConfig{"udmcfg.yaml": buildUDMConfig(free5gc)})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(reconciler.Get(context.Background(), types.NamespacedName{Name: "test-resource-udm-config", Namespace: "default"}, secret)).To(Succeed())
+			Expect(string(secret.Data["udmcfg.yaml"])).To(And(ContainSubstring("PrivateKey: c53c"), ContainSubstring("nrfUri:")))
+			Expect(config.secretFiles).To(ConsistOf(secretFile{
+				name: "udmcfg.yaml", secret: "test-resource-udm-config", key: "udmcfg.yaml", content: string(secret.Data["udmcfg.yaml"]),
+			}))
+		})
+
+		It("should render the default PCF services", func() {
+			configuration := render(buildPCFConfig(newFree5GC()))
+			Expect(configuration).To(HaveKeyWithValue("pcfName", "PCF"))
+			Expect(configuration["serviceList"]).To(ContainElement(And(
+				HaveKeyWithValue("serviceName", "npcf-smpolicycontrol"),
+				HaveKeyWithValue("suppFeat", "3fff"),
+			)))
+		})
+	})
+
+	Context("When rendering the PLMN of the core", func() {
+		tai := corev1alpha1.TAIConfig{PLMNID: plmnID, TAC: "000001"}
+		newFree5GC := func() *corev1alpha1.Free5GC {
+			return &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-resource",
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.Free5GCSpec{
+					PLMN: &corev1alpha1.PLMNSpec{
+						PLMNIDs:     []corev1alpha1.PLMNID{plmnID},
+						TAIs:        []corev1alpha1.TAIConfig{tai},
+						NetworkName: &corev1alpha1.NetworkNameConfig{Full: "Operator"},
+					},
+					MongoDB: &corev1alpha1.MongoDBSpec{Image: "mongo:4.4"},
+					NRF:     &corev1alpha1.NRFSpec{},
+					AMF:     &corev1alpha1.AMFSpec{AMFConfig: &corev1alpha1.AMFConfig{SupportDNNList: []string{"internet"}}},
+					SMF:     &corev1alpha1.SMFSpec{},
+					AUSF:    &corev1alpha1.AUSFSpec{},
+					NSSF:    &corev1alpha1.NSSFSpec{},
+				},
+			}
+		}
+
+		render := func(config renderedConfig) map[string]interface{} {
+			content, err := renderConfigFile("", config)
+			Expect(err).NotTo(HaveOccurred())
+			return content["configuration"].(map[string]interface{})
+		}
+		plmn := map[string]interface{}{"mcc": "208", "mnc": "93"}
+
+		It("should complete the AMF configuration", func() {
+			configuration := render(buildAMFConfig(newFree5GC()))
+			Expect(configuration["servedGuamiList"]).To(ConsistOf(map[string]interface{}{"plmnId": plmn, "amfId": defaultAMFID}))
+			Expect(configuration["supportTaiList"]).To(ConsistOf(map[string]interface{}{"plmnId": plmn, "tac": "000001"}))
+			Expect(configuration["plmnSupportList"]).To(ConsistOf(HaveKeyWithValue("plmnId", plmn)))
+			Expect(configuration["networkName"]).To(Equal(map[string]interface{}{"full": "Operator", "short": "free"}))
+		})
+
+		It("should let the AMF configuration override it", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.AMF.AMFConfig.SupportTAIList = []corev1alpha1.TAIConfig{{PLMNID: plmnID, TAC: "000002"}}
+
+			configuration := render(buildAMFConfig(free5gc))
+			Expect(configuration["supportTaiList"]).To(ConsistOf(HaveKeyWithValue("tac", "000002")))
+		})
+
+		It("should set the PLMN of the NRF, AUSF, SMF and NSSF", func() {
+			free5gc := newFree5GC()
+			Expect(render(buildNRFConfig(free5gc))).To(HaveKeyWithValue("DefaultPlmnId", plmn))
+			Expect(render(buildAUSFConfig(free5gc))).To(HaveKeyWithValue("plmnSupportList", ConsistOf(plmn)))
+
+			smfcfg, err := buildSMFConfig(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(render(smfcfg)).To(HaveKeyWithValue("plmnList", ConsistOf(plmn)))
+
+			nssf := render(buildNSSFConfig(free5gc))
+			Expect(nssf).To(HaveKeyWithValue("supportedPlmnList", ConsistOf(plmn)))
+			Expect(nssf["taList"]).To(ConsistOf(And(
+				HaveKeyWithValue("tai", map[string]interface{}{"plmnId": plmn, "tac": "000001"}),
+				HaveKeyWithValue("accessType", accessType3GPP),
+			)))
+		})
+
+		It("should leave the PLMN out when it is not set", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.PLMN = nil
+			Expect(render(buildAUSFConfig(free5gc))).NotTo(HaveKey("plmnSupportList"))
+			Expect(render(buildNSSFConfig(free5gc))).NotTo(HaveKey("taList"))
+		})
+	})
+
+	Context("When rendering the slices of the core", func() {
+		newFree5GC := func() *corev1alpha1.Free5GC {
+			return &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-resource",
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.Free5GCSpec{
+					PLMN: &corev1alpha1.PLMNSpec{
+						PLMNIDs: []corev1alpha1.PLMNID{plmnID},
+						TAIs:    []corev1alpha1.TAIConfig{{PLMNID: plmnID, TAC: "000001"}},
+					},
+					Slices: []corev1alpha1.SliceSpec{
+						{
+							SNSSAIConfig: corev1alpha1.SNSSAIConfig{SST: 1, SD: "010203"},
+							DNNs:         []corev1alpha1.SliceDNNSpec{{DNN: "internet", CIDR: "10.60.0.0/16"}},
+							UPFs:         []string{"upf1", "upf2"},
+						},
+						{
+							SNSSAIConfig: corev1alpha1.SNSSAIConfig{SST: 1, SD: "112233"},
+							DNNs:         []corev1alpha1.SliceDNNSpec{{DNN: "ims", CIDR: "10.61.0.0/16"}},
+							UPFs:         []string{"upf1", "upf3"},
+						},
+					},
+					NRF:  &corev1alpha1.NRFSpec{},
+					AMF:  &corev1alpha1.AMFSpec{},
+					SMF:  &corev1alpha1.SMFSpec{},
+					NSSF: &corev1alpha1.NSSFSpec{},
+					UPF: &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{
+						Enabled: true,
+						Instances: []corev1alpha1.UPFInstance{
+							{Name: "upf1", UPFConfig: &corev1alpha1.UPFConfig{GTPU: &corev1alpha1.GTPUConfig{IfList: []corev1alpha1.GTPUInterfaceConfig{
+								{Addr: "10.100.50.233", Type: "N3"},
+								{Addr: "10.100.50.132", Type: "N9"},
+							}}}},
+							{Name: "upf2", UPFConfig: &corev1alpha1.UPFConfig{GTPU: &corev1alpha1.GTPUConfig{IfList: []corev1alpha1.GTPUInterfaceConfig{
+								{Addr: "10.100.50.134", Type: "N9"},
+							}}}},
+							{Name: "upf3", UPFConfig: &corev1alpha1.UPFConfig{GTPU: &corev1alpha1.GTPUConfig{IfList: []corev1alpha1.GTPUInterfaceConfig{
+								{Addr: "10.100.50.136", Type: "N9"},
+							}}}},
+						},
+					}},
+				},
+			}
+		}
+
+		render := func(config renderedConfig) map[string]interface{} {
+			content, err := renderConfigFile("", config)
+			Expect(err).NotTo(HaveOccurred())
+			return content["configuration"].(map[string]interface{})
+		}
+		internet := map[string]interface{}{"sst": BeNumerically("==", 1), "sd": "010203"}
+		ims := map[string]interface{}{"sst": BeNumerically("==", 1), "sd": "112233"}
+		snssai := func(fields map[string]interface{}) OmegaMatcher {
+			return And(HaveKeyWithValue("sst", fields["sst"]), HaveKeyWithValue("sd", fields["sd"]))
+		}
+
+		It("should complete the AMF configuration", func() {
+			configuration := render(buildAMFConfig(newFree5GC()))
+			Expect(configuration["plmnSupportList"]).To(ConsistOf(HaveKeyWithValue("snssaiList", ConsistOf(snssai(internet), snssai(ims)))))
+			Expect(configuration["supportDnnList"]).To(ConsistOf("internet", "ims"))
+		})
+
+		It("should register the slices in the NSSF", func() {
+			configuration := render(buildNSSFConfig(newFree5GC()))
+			Expect(configuration["nsiList"]).To(ConsistOf(
+				And(
+					HaveKeyWithValue("snssai", snssai(internet)),
+					HaveKeyWithValue("nsiInformationList", ConsistOf(map[string]interface{}{
+						"nrfId": "http://test-resource-nrf:8000/nnrf-nfm/v1/nf-instances",
+						"nsiId": "1",
+					})),
+				),
+				HaveKeyWithValue("snssai", snssai(ims)),
+			))
+			Expect(configuration["supportedNssaiInPlmnList"]).To(ConsistOf(
+				HaveKeyWithValue("supportedSnssaiList", ConsistOf(snssai(internet), snssai(ims))),
+			))
+			Expect(configuration["taList"]).To(ConsistOf(
+				HaveKeyWithValue("supportedSnssaiList", ConsistOf(snssai(internet), snssai(ims))),
+			))
+		})
+
+		It("should derive the SMF slices and pin the pools to their anchor", func() {
+			free5gc := newFree5GC()
+			smfcfg, err := buildSMFConfig(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+
+			configuration := render(smfcfg)
+			Expect(configuration["snssaiInfos"]).To(ConsistOf(
+				And(HaveKeyWithValue("sNssai", snssai(internet)), HaveKeyWithValue("dnnInfos", ConsistOf(HaveKeyWithValue("dnn", "internet")))),
+				And(HaveKeyWithValue("sNssai", snssai(ims)), HaveKeyWithValue("dnnInfos", ConsistOf(HaveKeyWithValue("dnn", "ims")))),
+			))
+			Expect(smfUEIPPools(free5gc)).To(ConsistOf(
+				corev1alpha1.UEIPPoolConfig{DNN: "internet", Pools: []string{"10.60.0.0/16"}, UPF: "upf2"},
+				corev1alpha1.UEIPPoolConfig{DNN: "ims", Pools: []string{"10.61.0.0/16"}, UPF: "upf3"},
+			))
+		})
+
+		It("should only serve the slices of each UPF", func() {
+			free5gc := newFree5GC()
+			instances := free5gc.Spec.UPF.ULCL.Instances
+
+			Expect(upfConfig(free5gc, &instances[0], true).DNNList).To(ConsistOf(
+				corev1alpha1.UPFDNNConfig{DNN: "internet", CIDR: "10.60.0.0/16"},
+				corev1alpha1.UPFDNNConfig{DNN: "ims", CIDR: "10.61.0.0/16"},
+			))
+			Expect(upfConfig(free5gc, &instances[1], false).DNNList).To(ConsistOf(
+				corev1alpha1.UPFDNNConfig{DNN: "internet", CIDR: "10.60.0.0/16"},
+			))
+			Expect(upfConfig(free5gc, &instances[2], false).DNNList).To(ConsistOf(
+				corev1alpha1.UPFDNNConfig{DNN: "ims", CIDR: "10.61.0.0/16"},
+			))
+		})
+	})
+
+	Context("When rendering an IPv6 core", func() {
+		free5gc := &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resource",
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.Free5GCSpec{
+				PLMN: &corev1alpha1.PLMNSpec{
+					PLMNIDs: []corev1alpha1.PLMNID{plmnID},
+					TAIs:    []corev1alpha1.TAIConfig{{PLMNID: plmnID, TAC: "000001"}},
+				},
+				Network: corev1alpha1.NetworkSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+				},
+				Slices: []corev1alpha1.SliceSpec{{
+					SNSSAIConfig: corev1alpha1.SNSSAIConfig{SST: 1, SD: "010203"},
+					DNNs: []corev1alpha1.SliceDNNSpec{
+						{DNN: "internet", CIDR: "10.60.0.0/16", IPv6CIDR: "2001:db8:60::/48"},
+					},
+				}},
+				AMF: &corev1alpha1.AMFSpec{},
+				SMF: &corev1alpha1.SMFSpec{},
+				UPF: &corev1alpha1.UPFSpec{UPFConfig: &corev1alpha1.UPFConfig{GTPU: &corev1alpha1.GTPUConfig{
+					IfList: []corev1alpha1.GTPUInterfaceConfig{{Addr: "2001:db8:50::233", Type: "N3"}},
+				}}},
+			},
+		}
+
+		render := func(config renderedConfig) map[string]interface{} {
+			content, err := renderConfigFile("", config)
+			Expect(err).NotTo(HaveOccurred())
+			return content["configuration"].(map[string]interface{})
+		}
+
+		It("should bind the IPv6 wildcard address", func() {
+			configuration := render(buildAMFConfig(free5gc))
+			Expect(configuration["ngapIpList"]).To(Equal([]interface{}{"::"}))
+			Expect(configuration["sbi"]).To(HaveKeyWithValue("bindingIP", "::"))
+			Expect(configuration["sbi"]).To(HaveKeyWithValue("bindingIPv4", "0.0.0.0"))
+
+			smfcfg, err := buildSMFConfig(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(render(smfcfg)["pfcp"]).To(HaveKeyWithValue("listenAddr", "::"))
+
+			Expect(upfConfig(free5gc, nil, true).PFCP.Addr).To(Equal("::"))
+		})
+
+		It("should allocate the UEs an IPv4 and an IPv6 address", func() {
+			Expect(smfUEIPPools(free5gc)).To(ConsistOf(
+				corev1alpha1.UEIPPoolConfig{DNN: "internet", Pools: []string{"10.60.0.0/16", "2001:db8:60::/48"}},
+			))
+			Expect(upfConfig(free5gc, nil, true).DNNList).To(ConsistOf(
+				corev1alpha1.UPFDNNConfig{DNN: "internet", CIDR: "10.60.0.0/16"},
+				corev1alpha1.UPFDNNConfig{DNN: "internet", CIDR: "2001:db8:60::/48"},
+			))
+		})
+
+		It("should request dual-stack Services", func() {
+			svc := &corev1.Service{}
+			setIPFamilies(svc, free5gc)
+			Expect(svc.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+			Expect(svc.Spec.IPFamilyPolicy).To(HaveValue(Equal(corev1.IPFamilyPolicyRequireDualStack)))
+
+			svc = &corev1.Service{}
+			setIPFamilies(svc, &corev1alpha1.Free5GC{})
+			Expect(svc.Spec.IPFamilies).To(BeEmpty())
+			Expect(svc.Spec.IPFamilyPolicy).To(BeNil())
+		})
+	})
+
+	Context("When selecting the free5gc release", func() {
+		newFree5GC := func(version, registry string) *corev1alpha1.Free5GC {
+			return &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-resource",
+					Namespace: "default",
+				},
+				Spec: corev1alpha1.Free5GCSpec{
+					Version:       version,
+					ImageRegistry: registry,
+					MongoDB:       &corev1alpha1.MongoDBSpec{},
+					NRF:           &corev1alpha1.NRFSpec{},
+				},
+			}
+		}
+
+		It("should default the images to the version", func() {
+			Expect(componentImage(newFree5GC("", ""), "amf")).To(Equal("free5gc/amf:v3.3.0"))
+			Expect(componentImage(newFree5GC("v3.4.0", "registry.example.com/free5gc"), "amf")).
+				To(Equal("registry.example.com/free5gc/amf:v3.4.0"))
+			Expect(mongoDBImage(newFree5GC("", ""))).To(Equal("mongo:4.4"))
+		})
+
+		It("should keep the images set in the spec", func() {
+			free5gc := newFree5GC("v3.4.0", "")
+			Expect(componentImage(free5gc, "upf", "", "my/upf:dev")).To(Equal("my/upf:dev"))
+			Expect(componentImage(free5gc, "upf", "my/upf-ulcl:dev", "my/upf:dev")).To(Equal("my/upf-ulcl:dev"))
+		})
+
+		It("should render the configuration schema of the version", func() {
+			content, err := renderConfigFile("", buildNRFConfig(newFree5GC("v3.3.0", "")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content["info"]).To(HaveKeyWithValue("version", "1.0.2"))
+		})
+	})
+
+	Context("When computing the checksum of configuration files", func() {
+		It("should only change with the content of the files", func() {
+			checksum := dataChecksum(map[string]string{"amfcfg.yaml": "a", "uerouting.yaml": "b"})
+			Expect(dataChecksum(map[string]string{"uerouting.yaml": "b", "amfcfg.yaml": "a"})).To(Equal(checksum))
+			Expect(dataChecksum(map[string]string{"amfcfg.yaml": "a", "uerouting.yaml": "c"})).NotTo(Equal(checksum))
+			Expect(dataChecksum(map[string]string{"amfcfg.yaml": "ab"})).NotTo(Equal(dataChecksum(map[string]string{"amfcfg.yaml": "a", "b": ""})))
+		})
+	})
+
+	Context("When resolving configuration files from ConfigMaps and Secrets", func() {
+		free5gc := &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resource",
+				Namespace: "default",
+			},
+		}
+		reconciler := &Free5GCReconciler{
+			Client: fake.NewClientBuilder().WithObjects(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "amf-files", Namespace: "default"},
+					Data:       map[string]string{"amf.yaml": "configuration: {}\n"},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "amf-tls", Namespace: "default"},
+					Data:       map[string][]byte{"tls.key": []byte("key")},
+				},
+			).Build(),
+		}
+		optional := true
+
+		It("should return ConfigMap files by name and Secret files apart", func() {
+			files, secretFiles, err := reconciler.resolveConfigFrom(context.Background(), free5gc, []corev1alpha1.ConfigFileSource{
+				{
+					Name: "amfcfg.yaml",
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "amf-files"},
+						Key:                  "amf.yaml",
+					},
+				},
+				{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "amf-tls"},
+						Key:                  "tls.key",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal(map[string]string{"amfcfg.yaml": "configuration: {}\n"}))
+			Expect(secretFiles).To(ConsistOf(secretFile{name: "tls.key", secret: "amf-tls", key: "tls.key", content: "key"}))
+		})
+
+		It("should skip optional references only", func() {
+			missing := &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "amf.yaml",
+			}
+
+			_, _, err := reconciler.resolveConfigFrom(context.Background(), free5gc, []corev1alpha1.ConfigFileSource{{ConfigMapKeyRef: missing}})
+			Expect(err).To(HaveOccurred())
+
+			missing.Optional = &optional
+			files, _, err := reconciler.resolveConfigFrom(context.Background(), free5gc, []corev1alpha1.ConfigFileSource{{ConfigMapKeyRef: missing}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+
+		It("should reject entries without exactly one reference", func() {
+			_, _, err := reconciler.resolveConfigFrom(context.Background(), free5gc, []corev1alpha1.ConfigFileSource{{Name: "amfcfg.yaml"}})
+			Expect(err).To(MatchError(ContainSubstring("exactly one")))
+		})
+
+		It("should project Secret files next to the ConfigMap", func() {
+			template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "amf"}}}}
+			mountConfig(template, &componentConfig{
+				configMap:   "test-resource-amf-config",
+				secretFiles: []secretFile{{name: "amf.key", secret: "amf-tls", key: "tls.key"}},
+				checksum:    "abc",
+			})
+
+			Expect(template.Spec.Volumes).To(HaveLen(1))
+			projected := template.Spec.Volumes[0].Projected
+			Expect(projected).NotTo(BeNil())
+			Expect(projected.Sources).To(HaveLen(2))
+			Expect(projected.Sources[0].ConfigMap.Name).To(Equal("test-resource-amf-config"))
+			Expect(projected.Sources[1].Secret.Items).To(ConsistOf(corev1.KeyToPath{Key: "tls.key", Path: "amf.key"}))
+			Expect(template.Annotations).To(HaveKeyWithValue(configChecksumAnnotation, "abc"))
+		})
+
+		It("should index the referenced objects of every component", func() {
+			free5gc := free5gc.DeepCopy()
+			free5gc.Spec.AMF = &corev1alpha1.AMFSpec{ComponentSpec: corev1alpha1.ComponentSpec{
+				ConfigFrom: []corev1alpha1.ConfigFileSource{{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "amf-files"}},
+				}},
+			}}
+			free5gc.Spec.UPF = &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{
+				Enabled: true,
+				Instances: []corev1alpha1.UPFInstance{{Name: "upf1", ComponentSpec: corev1alpha1.ComponentSpec{
+					ConfigFrom: []corev1alpha1.ConfigFileSource{{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "upf-keys"}},
+					}},
+				}}},
+			}}
+
+			Expect(configFromReferences(free5gc)).To(ConsistOf("ConfigMap/amf-files", "Secret/upf-keys"))
+		})
+	})
+})
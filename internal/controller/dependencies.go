@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// startupTiers are the components in the order they are started. Each tier waits for
+// the components of the previous tiers to be ready: the network functions register
+// with the NRF, and read their subscription and policy data through the UDR. The WebUI
+// only needs MongoDB.
+var startupTiers = [][]string{
+	{"mongodb"},
+	{"nrf", "webui"},
+	{"udr"},
+	{"udm", "ausf", "pcf", "nssf"},
+	{"amf", "smf"},
+	{"upf", "n3iwf"},
+}
+
+// componentEnabled reports whether a component is deployed by the operator
+func componentEnabled(free5gc *corev1alpha1.Free5GC, component string) bool {
+	switch component {
+	case "mongodb":
+		return free5gc.Spec.MongoDB != nil && !free5gc.Spec.MongoDB.External
+	default:
+		return componentSpec(free5gc, component) != nil
+	}
+}
+
+// componentReady reports whether the replicas of a component are ready. The UPF is
+// ready when all its ULCL instances are.
+func componentReady(free5gc *corev1alpha1.Free5GC, component string) bool {
+	ready := func(status corev1alpha1.ComponentStatus, replicas *int32) bool {
+		desired := int32(1)
+		if replicas != nil {
+			desired = *replicas
+		}
+		return status.ReadyReplicas >= desired
+	}
+
+	switch component {
+	case "mongodb":
+		return ready(free5gc.Status.MongoDB, nil)
+	case "upf":
+		upf := free5gc.Spec.UPF
+		if upf.ULCL != nil && upf.ULCL.Enabled {
+			for i := range upf.ULCL.Instances {
+				instance := &upf.ULCL.Instances[i]
+				if !ready(free5gc.Status.Components[upfComponent(instance)], instance.Replicas) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return ready(free5gc.Status.Components[component], componentSpec(free5gc, component).Replicas)
+}
+
+// reconcileComponent reconciles a component of a startup tier
+func (r *Free5GCReconciler) reconcileComponent(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string) error {
+	switch component {
+	case "mongodb":
+		return r.reconcileMongoDB(ctx, free5gc)
+	case "upf":
+		return r.reconcileUPF(ctx, free5gc)
+	case "n3iwf", "webui":
+		return r.reconcileProvidedComponent(ctx, free5gc, component, componentSpec(free5gc, component))
+	}
+	for _, nf := range networkFunctions(free5gc) {
+		if nf.component == component {
+			return r.reconcileNetworkFunction(ctx, free5gc, nf)
+		}
+	}
+	return nil
+}
+
+// componentDeployments returns the names of the Deployments of a component
+func componentDeployments(free5gc *corev1alpha1.Free5GC, component string) []string {
+	if upf := free5gc.Spec.UPF; component == "upf" && upf.ULCL != nil && upf.ULCL.Enabled {
+		names := make([]string, 0, len(upf.ULCL.Instances))
+		for i := range upf.ULCL.Instances {
+			names = append(names, fmt.Sprintf("%s-%s", free5gc.Name, upfComponent(&upf.ULCL.Instances[i])))
+		}
+		return names
+	}
+	return []string{fmt.Sprintf("%s-%s", free5gc.Name, component)}
+}
+
+// componentStarted reports whether a Deployment of a component exists, in which case
+// the component was started and keeps being reconciled whatever its dependencies
+func (r *Free5GCReconciler) componentStarted(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string) (bool, error) {
+	for _, name := range componentDeployments(free5gc, component) {
+		err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: free5gc.Namespace}, &appsv1.Deployment{})
+		if err == nil {
+			return true, nil
+		}
+		if !errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get deployment %s: %w", name, err)
+		}
+	}
+	return false, nil
+}
+
+// reconcileTiers starts the components tier by tier. The components of a tier that are
+// not started yet are held back until the components of the previous tiers are ready,
+// which the Progressing condition reports, while the components already started keep
+// being reconciled. The Deployments of the components report their readiness,
+// triggering the reconciliation of the next tier.
+func (r *Free5GCReconciler) reconcileTiers(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
+	log := log.FromContext(ctx)
+
+	var waiting, blocked []string
+	for _, tier := range startupTiers {
+		var started []string
+		for _, component := range tier {
+			if !componentEnabled(free5gc, component) {
+				continue
+			}
+			if len(waiting) > 0 {
+				ok, err := r.componentStarted(ctx, free5gc, component)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					blocked = append(blocked, component)
+					continue
+				}
+			}
+			started = append(started, component)
+		}
+
+		for _, component := range started {
+			if err := r.reconcileComponent(ctx, free5gc, component); err != nil {
+				return err
+			}
+		}
+		for _, component := range started {
+			if !componentReady(free5gc, component) {
+				waiting = append(waiting, component)
+			}
+		}
+	}
+	if len(blocked) > 0 {
+		log.Info("Waiting for dependencies to be ready", "blocked", blocked, "waiting", waiting)
+	}
+	setProgressingCondition(free5gc, blocked, waiting)
+	return nil
+}
+
+// setProgressingCondition records whether the components are still starting, naming the
+// components held back by the components they are waiting for
+func setProgressingCondition(free5gc *corev1alpha1.Free5GC, blocked, waiting []string) {
+	condition := metav1.Condition{
+		Type:               corev1alpha1.ConditionProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Complete",
+		Message:            "All components are ready",
+		ObservedGeneration: free5gc.Generation,
+	}
+	switch {
+	case len(blocked) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "WaitingForDependencies"
+		condition.Message = fmt.Sprintf("Waiting for %s to be ready before starting %s",
+			strings.Join(waiting, ", "), strings.Join(blocked, ", "))
+	case len(waiting) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Starting"
+		condition.Message = fmt.Sprintf("Waiting for %s to be ready", strings.Join(waiting, ", "))
+	}
+	meta.SetStatusCondition(&free5gc.Status.Conditions, condition)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Startup tiers", func() {
+	ctx := context.Background()
+
+	newReconciler := func(free5gc *corev1alpha1.Free5GC) *Free5GCReconciler {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+		return &Free5GCReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(free5gc).Build(),
+			Scheme: scheme,
+		}
+	}
+
+	deployed := func(reconciler *Free5GCReconciler, component string) bool {
+		err := reconciler.Get(ctx, types.NamespacedName{Name: "test-resource-" + component, Namespace: "default"}, &appsv1.Deployment{})
+		if errors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	setReady := func(reconciler *Free5GCReconciler, component string) {
+		deploy := &appsv1.Deployment{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: "test-resource-" + component, Namespace: "default"}, deploy)).To(Succeed())
+		deploy.Status.Replicas = 1
+		deploy.Status.ReadyReplicas = 1
+		Expect(reconciler.Status().Update(ctx, deploy)).To(Succeed())
+	}
+
+	Context("When starting the components", func() {
+		It("should hold back each tier until its dependencies are ready", func() {
+			free5gc := &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+				Spec: corev1alpha1.Free5GCSpec{
+					MongoDB: &corev1alpha1.MongoDBSpec{},
+					NRF:     &corev1alpha1.NRFSpec{},
+					UDR:     &corev1alpha1.UDRSpec{},
+					WebUI:   &corev1alpha1.ComponentSpec{},
+				},
+			}
+			reconciler := newReconciler(free5gc)
+
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			Expect(deployed(reconciler, "mongodb")).To(BeTrue())
+			Expect(deployed(reconciler, "nrf")).To(BeFalse())
+			condition := meta.FindStatusCondition(free5gc.Status.Conditions, corev1alpha1.ConditionProgressing)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("WaitingForDependencies"))
+			Expect(condition.Message).To(Equal("Waiting for mongodb to be ready before starting nrf, webui, udr"))
+
+			setReady(reconciler, "mongodb")
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			Expect(deployed(reconciler, "nrf")).To(BeTrue())
+			Expect(deployed(reconciler, "webui")).To(BeTrue())
+			Expect(deployed(reconciler, "udr")).To(BeFalse())
+			Expect(meta.FindStatusCondition(free5gc.Status.Conditions, corev1alpha1.ConditionProgressing).Message).
+				To(Equal("Waiting for nrf, webui to be ready before starting udr"))
+
+			setReady(reconciler, "nrf")
+			setReady(reconciler, "webui")
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			Expect(deployed(reconciler, "udr")).To(BeTrue())
+			condition = meta.FindStatusCondition(free5gc.Status.Conditions, corev1alpha1.ConditionProgressing)
+			Expect(condition.Reason).To(Equal("Starting"))
+			Expect(condition.Message).To(Equal("Waiting for udr to be ready"))
+
+			setReady(reconciler, "udr")
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			condition = meta.FindStatusCondition(free5gc.Status.Conditions, corev1alpha1.ConditionProgressing)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("Complete"))
+		})
+
+		It("should keep reconciling the started components while a dependency is not ready", func() {
+			free5gc := &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+				Spec: corev1alpha1.Free5GCSpec{
+					MongoDB: &corev1alpha1.MongoDBSpec{External: true, URI: "mongodb://mongo.example.com:27017"},
+					NRF:     &corev1alpha1.NRFSpec{},
+					UDR:     &corev1alpha1.UDRSpec{},
+				},
+			}
+			reconciler := newReconciler(free5gc)
+
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			setReady(reconciler, "nrf")
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			Expect(deployed(reconciler, "udr")).To(BeTrue())
+
+			// The NRF is restarting when the UDR is scaled and the AUSF is added
+			deploy := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "test-resource-nrf", Namespace: "default"}, deploy)).To(Succeed())
+			deploy.Status.ReadyReplicas = 0
+			Expect(reconciler.Status().Update(ctx, deploy)).To(Succeed())
+			free5gc.Spec.UDR.Replicas = ptr.To(int32(2))
+			free5gc.Spec.AUSF = &corev1alpha1.AUSFSpec{}
+
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "test-resource-udr", Namespace: "default"}, deploy)).To(Succeed())
+			Expect(deploy.Spec.Replicas).To(Equal(ptr.To(int32(2))))
+			Expect(deployed(reconciler, "ausf")).To(BeFalse())
+			Expect(meta.FindStatusCondition(free5gc.Status.Conditions, corev1alpha1.ConditionProgressing).Message).
+				To(Equal("Waiting for nrf, udr to be ready before starting ausf"))
+		})
+
+		It("should not wait for an external MongoDB", func() {
+			free5gc := &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+				Spec: corev1alpha1.Free5GCSpec{
+					MongoDB: &corev1alpha1.MongoDBSpec{External: true, URI: "mongodb://mongo.example.com:27017"},
+					NRF:     &corev1alpha1.NRFSpec{},
+				},
+			}
+			reconciler := newReconciler(free5gc)
+
+			Expect(reconciler.reconcileTiers(ctx, free5gc)).To(Succeed())
+			Expect(deployed(reconciler, "mongodb")).To(BeFalse())
+			Expect(deployed(reconciler, "nrf")).To(BeTrue())
+		})
+	})
+})
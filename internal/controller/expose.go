@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// componentExpose returns the exposure of the first spec setting one, so that ULCL
+// instances inherit the exposure of the UPF
+func componentExpose(specs ...*corev1alpha1.ComponentSpec) *corev1alpha1.ExposeSpec {
+	for _, spec := range specs {
+		if spec != nil && spec.Expose != nil {
+			return spec.Expose
+		}
+	}
+	return nil
+}
+
+// exposedAnnotationsAnnotation lists the keys of the Service annotations set from
+// expose.annotations, so that the annotations removed from the spec are removed from
+// the Service without touching the ones set by others
+const exposedAnnotationsAnnotation = "core.free5gc.org/exposed-annotations"
+
+// exposeService sets the type, load balancer settings and annotations of the Service of
+// a component, and resets them when the component is no longer exposed. The annotations
+// are added to the ones set by others, such as the load balancer controllers.
+func exposeService(svc *corev1.Service, expose *corev1alpha1.ExposeSpec) {
+	if expose == nil {
+		expose = &corev1alpha1.ExposeSpec{}
+	}
+
+	for _, key := range strings.Split(svc.Annotations[exposedAnnotationsAnnotation], ",") {
+		if _, ok := expose.Annotations[key]; !ok {
+			delete(svc.Annotations, key)
+		}
+	}
+	delete(svc.Annotations, exposedAnnotationsAnnotation)
+	if len(expose.Annotations) > 0 {
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		keys := make([]string, 0, len(expose.Annotations))
+		for key, value := range expose.Annotations {
+			svc.Annotations[key] = value
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		svc.Annotations[exposedAnnotationsAnnotation] = strings.Join(keys, ",")
+	}
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	if expose.Type != "" {
+		svc.Spec.Type = expose.Type
+	}
+	svc.Spec.LoadBalancerIP = ""
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerIP = expose.LoadBalancerIP
+	}
+	svc.Spec.ExternalTrafficPolicy = ""
+	if svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.ExternalTrafficPolicy = expose.ExternalTrafficPolicy
+	}
+}
+
+// reconcileIngress routes the host of the Ingress of a component to its http port. The
+// Ingress is pruned once the component no longer has one.
+func (r *Free5GCReconciler) reconcileIngress(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, spec *corev1alpha1.ComponentSpec) error {
+	log := log.FromContext(ctx)
+
+	expose := componentExpose(spec)
+	if expose == nil || expose.Ingress == nil {
+		return nil
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", free5gc.Name, component),
+			Namespace: free5gc.Namespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+		if err := ctrl.SetControllerReference(free5gc, ingress, r.Scheme); err != nil {
+			return err
+		}
+
+		ingress.Labels = componentLabels(free5gc, component)
+		ingress.Labels["free5gc"] = free5gc.Name
+		ingress.Annotations = expose.Ingress.Annotations
+		pathType := networkingv1.PathTypePrefix
+		ingress.Spec = networkingv1.IngressSpec{
+			IngressClassName: expose.Ingress.ClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: expose.Ingress.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: fmt.Sprintf("%s-%s", free5gc.Name, component),
+									Port: networkingv1.ServiceBackendPort{Name: "http"},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		}
+		if expose.Ingress.TLSSecretName != "" {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{
+				Hosts:      []string{expose.Ingress.Host},
+				SecretName: expose.Ingress.TLSSecretName,
+			}}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ingress for %s: %w", component, err)
+	}
+
+	log.Info("Reconciled ingress", "component", component, "operation", op)
+	return nil
+}
+
+// externalEndpoints returns the addresses a component is reachable on from outside the
+// cluster: the ports of its load balancer once it is provisioned, the node ports of a
+// NodePort Service, and the host of its Ingress
+func (r *Free5GCReconciler) externalEndpoints(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string) ([]corev1alpha1.ExternalEndpoint, error) {
+	key := client.ObjectKey{Name: fmt.Sprintf("%s-%s", free5gc.Name, component), Namespace: free5gc.Namespace}
+
+	var endpoints []corev1alpha1.ExternalEndpoint
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, key, svc); err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get service for %s: %w", component, err)
+	}
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		for _, lb := range svc.Status.LoadBalancer.Ingress {
+			host := lb.IP
+			if host == "" {
+				host = lb.Hostname
+			}
+			for _, port := range svc.Spec.Ports {
+				endpoints = append(endpoints, corev1alpha1.ExternalEndpoint{
+					Name: port.Name, Host: host, Port: port.Port, Protocol: port.Protocol,
+				})
+			}
+		}
+	case corev1.ServiceTypeNodePort:
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort != 0 {
+				endpoints = append(endpoints, corev1alpha1.ExternalEndpoint{
+					Name: port.Name, Port: port.NodePort, Protocol: port.Protocol,
+				})
+			}
+		}
+	}
+
+	ingress := &networkingv1.Ingress{}
+	if err := r.Get(ctx, key, ingress); err != nil {
+		if errors.IsNotFound(err) {
+			return endpoints, nil
+		}
+		return nil, fmt.Errorf("failed to get ingress for %s: %w", component, err)
+	}
+	port := int32(80)
+	if len(ingress.Spec.TLS) > 0 {
+		port = 443
+	}
+	for _, rule := range ingress.Spec.Rules {
+		endpoints = append(endpoints, corev1alpha1.ExternalEndpoint{
+			Name: "ingress", Host: rule.Host, Port: port, Protocol: corev1.ProtocolTCP,
+		})
+	}
+	return endpoints, nil
+}
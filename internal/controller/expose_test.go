@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("External exposure", func() {
+	ctx := context.Background()
+
+	newReconciler := func(free5gc *corev1alpha1.Free5GC) *Free5GCReconciler {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+		return &Free5GCReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(free5gc).Build(),
+			Scheme: scheme,
+		}
+	}
+
+	newFree5GC := func() *corev1alpha1.Free5GC {
+		return &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+			Spec: corev1alpha1.Free5GCSpec{
+				AMF: &corev1alpha1.AMFSpec{ComponentSpec: corev1alpha1.ComponentSpec{
+					Expose: &corev1alpha1.ExposeSpec{
+						Type:                  corev1.ServiceTypeLoadBalancer,
+						LoadBalancerIP:        "192.0.2.10",
+						Annotations:           map[string]string{"metallb.universe.tf/address-pool": "ran"},
+						ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+					},
+				}},
+				WebUI: &corev1alpha1.ComponentSpec{
+					Expose: &corev1alpha1.ExposeSpec{
+						Ingress: &corev1alpha1.IngressSpec{Host: "webui.example.com", TLSSecretName: "webui-tls"},
+					},
+				},
+			},
+		}
+	}
+
+	Context("When reconciling the Service of an exposed component", func() {
+		It("should apply the Service type and load balancer settings", func() {
+			free5gc := newFree5GC()
+			reconciler := newReconciler(free5gc)
+			Expect(reconciler.reconcileService(ctx, free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec)).To(Succeed())
+
+			svc := &corev1.Service{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "test-resource-amf", Namespace: "default"}, svc)).To(Succeed())
+			Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(svc.Spec.LoadBalancerIP).To(Equal("192.0.2.10"))
+			Expect(svc.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyLocal))
+			Expect(svc.Annotations).To(HaveKeyWithValue("metallb.universe.tf/address-pool", "ran"))
+		})
+
+		It("should reset the Service once the component is no longer exposed", func() {
+			free5gc := newFree5GC()
+			reconciler := newReconciler(free5gc)
+			Expect(reconciler.reconcileService(ctx, free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec)).To(Succeed())
+
+			// An annotation set by a load balancer controller
+			svc := &corev1.Service{}
+			key := types.NamespacedName{Name: "test-resource-amf", Namespace: "default"}
+			Expect(reconciler.Get(ctx, key, svc)).To(Succeed())
+			svc.Annotations["metallb.universe.tf/ip-allocated-from-pool"] = "ran"
+			Expect(reconciler.Update(ctx, svc)).To(Succeed())
+
+			free5gc.Spec.AMF.Expose = nil
+			Expect(reconciler.reconcileService(ctx, free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec)).To(Succeed())
+			Expect(reconciler.Get(ctx, key, svc)).To(Succeed())
+			Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			Expect(svc.Spec.LoadBalancerIP).To(BeEmpty())
+			Expect(svc.Spec.ExternalTrafficPolicy).To(BeEmpty())
+			Expect(svc.Annotations).To(Equal(map[string]string{"metallb.universe.tf/ip-allocated-from-pool": "ran"}))
+		})
+
+		It("should report the load balancer and node ports as endpoints", func() {
+			free5gc := newFree5GC()
+			reconciler := newReconciler(free5gc)
+			Expect(reconciler.reconcileService(ctx, free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec)).To(Succeed())
+
+			svc := &corev1.Service{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "test-resource-amf", Namespace: "default"}, svc)).To(Succeed())
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}}
+			Expect(reconciler.Status().Update(ctx, svc)).To(Succeed())
+
+			endpoints, err := reconciler.externalEndpoints(ctx, free5gc, "amf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints).To(ContainElement(corev1alpha1.ExternalEndpoint{
+				Name: "ngap", Host: "192.0.2.10", Port: 38412, Protocol: corev1.ProtocolSCTP,
+			}))
+
+			svc.Spec.Type = corev1.ServiceTypeNodePort
+			svc.Spec.Ports[1].NodePort = 30412
+			Expect(reconciler.Update(ctx, svc)).To(Succeed())
+			endpoints, err = reconciler.externalEndpoints(ctx, free5gc, "amf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints).To(Equal([]corev1alpha1.ExternalEndpoint{
+				{Name: "ngap", Port: 30412, Protocol: corev1.ProtocolSCTP},
+			}))
+		})
+	})
+
+	Context("When reconciling the Ingress of the WebUI", func() {
+		It("should route the host to the WebUI over TLS", func() {
+			free5gc := newFree5GC()
+			reconciler := newReconciler(free5gc)
+			Expect(reconciler.reconcileIngress(ctx, free5gc, "webui", free5gc.Spec.WebUI)).To(Succeed())
+
+			key := types.NamespacedName{Name: "test-resource-webui", Namespace: "default"}
+			ingress := &networkingv1.Ingress{}
+			Expect(reconciler.Get(ctx, key, ingress)).To(Succeed())
+			Expect(ingress.Spec.Rules).To(HaveLen(1))
+			Expect(ingress.Spec.Rules[0].Host).To(Equal("webui.example.com"))
+			Expect(ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name).To(Equal("http"))
+			Expect(ingress.Spec.TLS).To(Equal([]networkingv1.IngressTLS{{
+				Hosts: []string{"webui.example.com"}, SecretName: "webui-tls",
+			}}))
+
+			endpoints, err := reconciler.externalEndpoints(ctx, free5gc, "webui")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoints).To(Equal([]corev1alpha1.ExternalEndpoint{
+				{Name: "ingress", Host: "webui.example.com", Port: 443, Protocol: corev1.ProtocolTCP},
+			}))
+		})
+	})
+})
@@ -19,36 +19,78 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/yaml"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+	"github.com/Kyuzial/free5gc-k8s/internal/release"
 )
 
 const (
 	finalizerName = "free5gc.core.free5gc.org/finalizer"
+	// configFromIndex indexes Free5GCs by the ConfigMaps and Secrets referenced in configFrom
+	configFromIndex = ".spec.configFrom"
 )
 
 // Free5GCReconciler reconciles a Free5GC object
 type Free5GCReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
-// Helper function to create or update a deployment
-func (r *Free5GCReconciler) reconcileDeployment(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, spec *corev1alpha1.ComponentSpec) error {
+// componentImage returns the first image set in the specs of a component, or the image
+// of the network function nf in the free5gc version
+func componentImage(free5gc *corev1alpha1.Free5GC, nf string, images ...string) string {
+	for _, image := range images {
+		if image != "" {
+			return image
+		}
+	}
+	r, err := release.Lookup(free5gc.Spec.Version)
+	if err != nil {
+		return ""
+	}
+	return r.Image(free5gc.Spec.ImageRegistry, nf)
+}
+
+// mongoDBImage returns the MongoDB image set in the spec, or the MongoDB image
+// of the free5gc version
+func mongoDBImage(free5gc *corev1alpha1.Free5GC) string {
+	if free5gc.Spec.MongoDB.Image != "" {
+		return free5gc.Spec.MongoDB.Image
+	}
+	r, err := release.Lookup(free5gc.Spec.Version)
+	if err != nil {
+		return ""
+	}
+	return r.MongoDBImage
+}
+
+// Helper function to create or update a deployment. When config is set, it is
+// mounted as the component's configuration directory.
+func (r *Free5GCReconciler) reconcileDeployment(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, spec *corev1alpha1.ComponentSpec, config *componentConfig) error {
 	log := log.FromContext(ctx)
 
 	if spec == nil {
@@ -63,6 +105,10 @@ func (r *Free5GCReconciler) reconcileDeployment(ctx context.Context, free5gc *co
 		},
 	}
 
+	if recreating, err := r.recreateForSelector(ctx, free5gc, deploy.Name, componentLabels(free5gc, component)); err != nil || recreating {
+		return err
+	}
+
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deploy, func() error {
 		if err := ctrl.SetControllerReference(free5gc, deploy, r.Scheme); err != nil {
 			return err
@@ -73,26 +119,22 @@ func (r *Free5GCReconciler) reconcileDeployment(ctx context.Context, free5gc *co
 			replicas = *spec.Replicas
 		}
 
+		deploy.Labels = componentLabels(free5gc, component)
 		deploy.Spec = appsv1.DeploymentSpec{
 			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":       "free5gc",
-					"component": component,
-				},
+				MatchLabels: componentLabels(free5gc, component),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":       "free5gc",
-						"component": component,
-					},
+					Labels: componentLabels(free5gc, component),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:  component,
-							Image: spec.Image,
+							Name:      component,
+							Image:     componentImage(free5gc, component, spec.Image),
+							Ports:     containerPorts(componentPorts(free5gc, component, spec)),
 							Resources: spec.Resources,
 							Env: []corev1.EnvVar{
 								{
@@ -110,6 +152,20 @@ func (r *Free5GCReconciler) reconcileDeployment(ctx context.Context, free5gc *co
 			},
 		}
 
+		// Probe the component so that it is only ready once it serves its ports
+		setProbes(&deploy.Spec.Template.Spec.Containers[0],
+			probeHandler(free5gc, component, componentPorts(free5gc, component, spec), spec.Probes), spec.Probes)
+
+		// Attach the networks of the reference points of the component
+		if err := setNetworkSelection(&deploy.Spec.Template, free5gc, component, componentNetworks(component, spec),
+			componentAddresses(free5gc, component)); err != nil {
+			return err
+		}
+
+		if config != nil {
+			mountConfig(&deploy.Spec.Template, config)
+		}
+
 		return nil
 	})
 
@@ -122,7 +178,7 @@ func (r *Free5GCReconciler) reconcileDeployment(ctx context.Context, free5gc *co
 }
 
 // Helper function to create or update a service
-func (r *Free5GCReconciler) reconcileService(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string) error {
+func (r *Free5GCReconciler) reconcileService(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, spec *corev1alpha1.ComponentSpec) error {
 	log := log.FromContext(ctx)
 
 	svc := &corev1.Service{
@@ -137,19 +193,13 @@ func (r *Free5GCReconciler) reconcileService(ctx context.Context, free5gc *corev
 			return err
 		}
 
+		svc.Labels = componentLabels(free5gc, component)
 		svc.Spec = corev1.ServiceSpec{
-			Selector: map[string]string{
-				"app":       "free5gc",
-				"component": component,
-			},
-			Ports: []corev1.ServicePort{
-				{
-					Name:     "http",
-					Protocol: corev1.ProtocolTCP,
-					Port:     80,
-				},
-			},
+			Selector: componentLabels(free5gc, component),
+			Ports:    servicePorts(componentPorts(free5gc, component, spec)),
 		}
+		setIPFamilies(svc, free5gc)
+		exposeService(svc, componentExpose(spec))
 
 		return nil
 	})
@@ -162,8 +212,9 @@ func (r *Free5GCReconciler) reconcileService(ctx context.Context, free5gc *corev
 	return nil
 }
 
-// Helper function to update component status
-func (r *Free5GCReconciler) updateComponentStatus(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string) error {
+// Helper function to update component status. refPoints are the reference points of
+// the networks expected on the pods of the component.
+func (r *Free5GCReconciler) updateComponentStatus(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, refPoints []string) error {
 	deploy := &appsv1.Deployment{}
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      fmt.Sprintf("%s-%s", free5gc.Name, component),
@@ -192,19 +243,82 @@ func (r *Free5GCReconciler) updateComponentStatus(ctx context.Context, free5gc *
 		status.Message = fmt.Sprintf("Waiting for %d/%d replicas to be ready", deploy.Status.ReadyReplicas, deploy.Status.Replicas)
 	}
 
+	// Report the networks Multus attached to the running pods. Pods that are not running
+	// yet may not be attached to their networks.
+	if len(refPoints) > 0 {
+		selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+		if err != nil {
+			return err
+		}
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(free5gc.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return fmt.Errorf("failed to list the pods of %s: %w", component, err)
+		}
+		slices.SortFunc(pods.Items, func(a, b corev1.Pod) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		for i := range pods.Items {
+			if pod := &pods.Items[i]; pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
+				status.Pods = append(status.Pods, podNetworkStatus(pod, free5gc, refPoints))
+			}
+		}
+	}
+
+	endpoints, err := r.externalEndpoints(ctx, free5gc, component)
+	if err != nil {
+		return err
+	}
+	status.Endpoints = endpoints
+
 	free5gc.Status.Components[component] = status
 	return nil
 }
 
+// setNetworksAttachedCondition records whether the running pods of the components are
+// attached to all their networks
+func setNetworksAttachedCondition(free5gc *corev1alpha1.Free5GC) {
+	components := make([]string, 0, len(free5gc.Status.Components))
+	for component := range free5gc.Status.Components {
+		components = append(components, component)
+	}
+	slices.Sort(components)
+
+	var missing []string
+	for _, component := range components {
+		for _, pod := range free5gc.Status.Components[component].Pods {
+			if len(pod.MissingNetworks) > 0 {
+				missing = append(missing, fmt.Sprintf("%s (%s)", pod.Name, strings.Join(pod.MissingNetworks, ", ")))
+			}
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               corev1alpha1.ConditionNetworksAttached,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Attached",
+		Message:            "The running pods are attached to all their networks",
+		ObservedGeneration: free5gc.Generation,
+	}
+	if len(missing) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "AttachmentMissing"
+		condition.Message = "Networks not attached to pods: " + strings.Join(missing, "; ")
+	}
+	meta.SetStatusCondition(&free5gc.Status.Conditions, condition)
+}
+
 // +kubebuilder:rbac:groups=core.free5gc.org,resources=free5gcs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core.free5gc.org,resources=free5gcs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core.free5gc.org,resources=free5gcs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="k8s.cni.cncf.io",resources=networkattachmentdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="k8s.cni.cncf.io",resources=networkattachmentdefinitions,verbs=get;list;watch;create;update;patch;delete
 
 func (r *Free5GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -244,52 +358,34 @@ func (r *Free5GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, nil
 	}
 
-	// Reconcile MongoDB if specified
-	if free5gc.Spec.MongoDB != nil && !free5gc.Spec.MongoDB.External {
-		if err := r.reconcileMongoDB(ctx, free5gc); err != nil {
-			return ctrl.Result{}, err
-		}
+	// The free5gc version selects the images and configuration schema of every component
+	if _, err := release.Lookup(free5gc.Spec.Version); err != nil {
+		log.Error(err, "Invalid free5gc version")
+		return ctrl.Result{}, err
 	}
 
-	// Reconcile core components
-	components := map[string]*corev1alpha1.ComponentSpec{
-		"nrf":    free5gc.Spec.NRF,
-		"amf":    free5gc.Spec.AMF,
-		"smf":    free5gc.Spec.SMF,
-		"ausf":   free5gc.Spec.AUSF,
-		"pcf":    free5gc.Spec.PCF,
-		"udm":    free5gc.Spec.UDM,
-		"udr":    free5gc.Spec.UDR,
-		"n3iwf":  free5gc.Spec.N3IWF,
-		"webui":  free5gc.Spec.WebUI,
+	// Create the network attachments before the pods attached to them
+	if err := r.reconcileNetworkAttachments(ctx, free5gc); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	// Reconcile NSSF separately due to its special configuration
-	if free5gc.Spec.NSSF != nil {
-		if err := r.reconcileNSSF(ctx, free5gc); err != nil {
-			return ctrl.Result{}, err
-		}
+	// Allocate the addresses of the components, used by their configurations and pods
+	if err := r.reconcileAddresses(ctx, free5gc); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	for component, spec := range components {
-		if err := r.reconcileDeployment(ctx, free5gc, component, spec); err != nil {
-			return ctrl.Result{}, err
-		}
-		if err := r.reconcileService(ctx, free5gc, component); err != nil {
-			return ctrl.Result{}, err
-		}
-		if err := r.updateComponentStatus(ctx, free5gc, component); err != nil {
-			return ctrl.Result{}, err
-		}
+	// Start the components in the order of their dependencies
+	if err := r.reconcileTiers(ctx, free5gc); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	// Reconcile UPF separately due to its special configuration
-	if free5gc.Spec.UPF != nil {
-		if err := r.reconcileUPF(ctx, free5gc); err != nil {
-			return ctrl.Result{}, err
-		}
+	// Delete the resources of the components removed from the spec
+	if err := r.pruneResources(ctx, free5gc); err != nil {
+		return ctrl.Result{}, err
 	}
 
+	setNetworksAttachedCondition(free5gc)
+
 	// Update status
 	if err := r.Status().Update(ctx, free5gc); err != nil {
 		log.Error(err, "Failed to update Free5GC status")
@@ -306,7 +402,8 @@ func (r *Free5GCReconciler) reconcileMongoDB(ctx context.Context, free5gc *corev
 		return nil
 	}
 
-	// Create PVC if storage is specified
+	// Create PVC if storage is specified, or adopt the PVC retained by an earlier Free5GC
+	// of the same name
 	if free5gc.Spec.MongoDB.Storage != nil {
 		pvc := &corev1.PersistentVolumeClaim{
 			ObjectMeta: metav1.ObjectMeta{
@@ -316,15 +413,27 @@ func (r *Free5GCReconciler) reconcileMongoDB(ctx context.Context, free5gc *corev
 		}
 
 		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, pvc, func() error {
-			if err := ctrl.SetControllerReference(free5gc, pvc, r.Scheme); err != nil {
+			if err := r.setStorageOwner(free5gc, pvc); err != nil {
 				return err
 			}
+			pvc.Labels = mongoDBLabels(free5gc)
 
 			quantity, err := resource.ParseQuantity(free5gc.Spec.MongoDB.Storage.Size)
 			if err != nil {
 				return fmt.Errorf("invalid storage size: %w", err)
 			}
 
+			// The spec of a claim cannot be changed once created, except to expand it
+			if pvc.ResourceVersion != "" {
+				if quantity.Cmp(pvc.Spec.Resources.Requests[corev1.ResourceStorage]) > 0 {
+					if pvc.Spec.Resources.Requests == nil {
+						pvc.Spec.Resources.Requests = corev1.ResourceList{}
+					}
+					pvc.Spec.Resources.Requests[corev1.ResourceStorage] = quantity
+				}
+				return nil
+			}
+
 			pvc.Spec = corev1.PersistentVolumeClaimSpec{
 				AccessModes: []corev1.PersistentVolumeAccessMode{
 					corev1.ReadWriteOnce,
@@ -363,26 +472,21 @@ func (r *Free5GCReconciler) reconcileMongoDB(ctx context.Context, free5gc *corev
 		}
 
 		replicas := int32(1)
+		deploy.Labels = mongoDBLabels(free5gc)
 		deploy.Spec = appsv1.DeploymentSpec{
 			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":       "mongodb",
-					"free5gc":   free5gc.Name,
-				},
+				MatchLabels: mongoDBSelector(free5gc),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":       "mongodb",
-						"free5gc":   free5gc.Name,
-					},
+					Labels: mongoDBLabels(free5gc),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
 							Name:  "mongodb",
-							Image: free5gc.Spec.MongoDB.Image,
+							Image: mongoDBImage(free5gc),
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          "mongodb",
@@ -402,6 +506,10 @@ func (r *Free5GCReconciler) reconcileMongoDB(ctx context.Context, free5gc *corev
 			},
 		}
 
+		setProbes(&deploy.Spec.Template.Spec.Containers[0], &corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sh", "-c", mongoDBPing}},
+		}, free5gc.Spec.MongoDB.Probes)
+
 		// Add volume if storage is configured
 		if free5gc.Spec.MongoDB.Storage != nil {
 			deploy.Spec.Template.Spec.Volumes = []corev1.Volume{
@@ -446,20 +554,19 @@ func (r *Free5GCReconciler) reconcileMongoDB(ctx context.Context, free5gc *corev
 			return err
 		}
 
+		svc.Labels = mongoDBLabels(free5gc)
 		svc.Spec = corev1.ServiceSpec{
-			Selector: map[string]string{
-				"app":       "mongodb",
-				"free5gc":   free5gc.Name,
-			},
+			Selector: mongoDBSelector(free5gc),
 			Ports: []corev1.ServicePort{
 				{
-					Name:     "mongodb",
-					Protocol: corev1.ProtocolTCP,
-					Port:     27017,
+					Name:       "mongodb",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       27017,
 					TargetPort: intstr.FromInt(27017),
 				},
 			},
 		}
+		setIPFamilies(svc, free5gc)
 
 		return nil
 	})
@@ -507,9 +614,9 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 
 	// Handle ULCL-enabled configuration
 	if free5gc.Spec.UPF.ULCL != nil && free5gc.Spec.UPF.ULCL.Enabled {
-		// Create UPF instances for ULCL
-		for _, instance := range free5gc.Spec.UPF.ULCL.Instances {
-			if err := r.reconcileUPFInstance(ctx, free5gc, instance); err != nil {
+		// Create UPF instances for ULCL, the first one being connected to the RAN
+		for i, instance := range free5gc.Spec.UPF.ULCL.Instances {
+			if err := r.reconcileUPFInstance(ctx, free5gc, instance, i == 0); err != nil {
 				return err
 			}
 		}
@@ -517,6 +624,13 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 	}
 
 	// Handle standard UPF configuration
+	config, err := r.reconcileConfigMap(ctx, free5gc, "upf", &free5gc.Spec.UPF.ComponentSpec, map[string]renderedConfig{
+		"upfcfg.yaml": buildUPFConfig(free5gc, nil, true),
+	})
+	if err != nil {
+		return err
+	}
+
 	deploy := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-upf", free5gc.Name),
@@ -524,6 +638,10 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 		},
 	}
 
+	if recreating, err := r.recreateForSelector(ctx, free5gc, deploy.Name, componentLabels(free5gc, "upf")); err != nil || recreating {
+		return err
+	}
+
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deploy, func() error {
 		if err := ctrl.SetControllerReference(free5gc, deploy, r.Scheme); err != nil {
 			return err
@@ -534,26 +652,22 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 			replicas = *free5gc.Spec.UPF.Replicas
 		}
 
+		deploy.Labels = componentLabels(free5gc, "upf")
 		deploy.Spec = appsv1.DeploymentSpec{
 			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":       "free5gc",
-					"component": "upf",
-				},
+				MatchLabels: componentLabels(free5gc, "upf"),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":       "free5gc",
-						"component": "upf",
-					},
+					Labels: componentLabels(free5gc, "upf"),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
 							Name:      "upf",
-							Image:     free5gc.Spec.UPF.Image,
+							Image:     componentImage(free5gc, "upf", free5gc.Spec.UPF.Image),
+							Ports:     containerPorts(componentPorts(free5gc, "upf", &free5gc.Spec.UPF.ComponentSpec)),
 							Resources: free5gc.Spec.UPF.Resources,
 							SecurityContext: &corev1.SecurityContext{
 								Capabilities: &corev1.Capabilities{
@@ -576,23 +690,18 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 			},
 		}
 
-		// Add network interfaces if specified
-		if free5gc.Spec.Network.N3Network != nil {
-			deploy.Spec.Template.Annotations = map[string]string{
-				"k8s.v1.cni.cncf.io/networks": free5gc.Spec.Network.N3Network.Name,
-			}
-		}
-		if free5gc.Spec.Network.N4Network != nil {
-			if deploy.Spec.Template.Annotations == nil {
-				deploy.Spec.Template.Annotations = make(map[string]string)
-			}
-			if _, ok := deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"]; ok {
-				deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] += "," + free5gc.Spec.Network.N4Network.Name
-			} else {
-				deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] = free5gc.Spec.Network.N4Network.Name
-			}
+		setProbes(&deploy.Spec.Template.Spec.Containers[0],
+			probeHandler(free5gc, "upf", componentPorts(free5gc, "upf", &free5gc.Spec.UPF.ComponentSpec), free5gc.Spec.UPF.Probes),
+			free5gc.Spec.UPF.Probes)
+
+		// Attach the networks, requesting the addresses of the UPF configuration
+		if err := setNetworkSelection(&deploy.Spec.Template, free5gc, "upf", componentNetworks("upf", &free5gc.Spec.UPF.ComponentSpec),
+			upfAddresses(upfConfig(free5gc, nil, true))); err != nil {
+			return err
 		}
 
+		mountConfig(&deploy.Spec.Template, config)
+
 		return nil
 	})
 
@@ -614,20 +723,13 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 			return err
 		}
 
+		svc.Labels = componentLabels(free5gc, "upf")
 		svc.Spec = corev1.ServiceSpec{
-			Selector: map[string]string{
-				"app":       "free5gc",
-				"component": "upf",
-			},
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "pfcp",
-					Protocol:   corev1.ProtocolUDP,
-					Port:       8805,
-					TargetPort: intstr.FromInt(8805),
-				},
-			},
+			Selector: componentLabels(free5gc, "upf"),
+			Ports:    servicePorts(componentPorts(free5gc, "upf", &free5gc.Spec.UPF.ComponentSpec)),
 		}
+		setIPFamilies(svc, free5gc)
+		exposeService(svc, componentExpose(&free5gc.Spec.UPF.ComponentSpec))
 
 		return nil
 	})
@@ -637,12 +739,20 @@ func (r *Free5GCReconciler) reconcileUPF(ctx context.Context, free5gc *corev1alp
 	}
 	log.Info("Reconciled UPF service", "operation", op)
 
-	return nil
+	return r.updateComponentStatus(ctx, free5gc, "upf", componentNetworks("upf", &free5gc.Spec.UPF.ComponentSpec))
 }
 
-func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *corev1alpha1.Free5GC, instance corev1alpha1.UPFInstance) error {
+func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *corev1alpha1.Free5GC, instance corev1alpha1.UPFInstance, uplink bool) error {
 	log := log.FromContext(ctx)
 
+	component := upfComponent(&instance)
+	config, err := r.reconcileConfigMap(ctx, free5gc, component, &instance.ComponentSpec, map[string]renderedConfig{
+		"upfcfg.yaml": buildUPFConfig(free5gc, &instance, uplink),
+	})
+	if err != nil {
+		return err
+	}
+
 	deploy := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-upf-%s", free5gc.Name, instance.Name),
@@ -650,6 +760,10 @@ func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *c
 		},
 	}
 
+	if recreating, err := r.recreateForSelector(ctx, free5gc, deploy.Name, upfInstanceLabels(free5gc, instance.Name)); err != nil || recreating {
+		return err
+	}
+
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deploy, func() error {
 		if err := ctrl.SetControllerReference(free5gc, deploy, r.Scheme); err != nil {
 			return err
@@ -660,28 +774,22 @@ func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *c
 			replicas = *instance.Replicas
 		}
 
+		deploy.Labels = upfInstanceLabels(free5gc, instance.Name)
 		deploy.Spec = appsv1.DeploymentSpec{
 			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":       "free5gc",
-					"component": "upf",
-					"instance":  instance.Name,
-				},
+				MatchLabels: upfInstanceLabels(free5gc, instance.Name),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":       "free5gc",
-						"component": "upf",
-						"instance":  instance.Name,
-					},
+					Labels: upfInstanceLabels(free5gc, instance.Name),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
 							Name:      "upf",
-							Image:     instance.Image,
+							Image:     componentImage(free5gc, "upf", instance.Image, free5gc.Spec.UPF.Image),
+							Ports:     containerPorts(componentPorts(free5gc, "upf", &instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec)),
 							Resources: instance.Resources,
 							SecurityContext: &corev1.SecurityContext{
 								Capabilities: &corev1.Capabilities{
@@ -708,33 +816,20 @@ func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *c
 			},
 		}
 
-		// Add network interfaces if specified
-		if free5gc.Spec.Network.N3Network != nil {
-			deploy.Spec.Template.Annotations = map[string]string{
-				"k8s.v1.cni.cncf.io/networks": free5gc.Spec.Network.N3Network.Name,
-			}
-		}
-		if free5gc.Spec.Network.N4Network != nil {
-			if deploy.Spec.Template.Annotations == nil {
-				deploy.Spec.Template.Annotations = make(map[string]string)
-			}
-			if _, ok := deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"]; ok {
-				deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] += "," + free5gc.Spec.Network.N4Network.Name
-			} else {
-				deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] = free5gc.Spec.Network.N4Network.Name
-			}
-		}
-		if free5gc.Spec.Network.N9Network != nil {
-			if deploy.Spec.Template.Annotations == nil {
-				deploy.Spec.Template.Annotations = make(map[string]string)
-			}
-			if _, ok := deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"]; ok {
-				deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] += "," + free5gc.Spec.Network.N9Network.Name
-			} else {
-				deploy.Spec.Template.Annotations["k8s.v1.cni.cncf.io/networks"] = free5gc.Spec.Network.N9Network.Name
-			}
+		probes := componentProbes(&instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec)
+		setProbes(&deploy.Spec.Template.Spec.Containers[0],
+			probeHandler(free5gc, "upf", componentPorts(free5gc, "upf", &instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec), probes),
+			probes)
+
+		// Attach the networks, requesting the addresses of the instance configuration
+		if err := setNetworkSelection(&deploy.Spec.Template, free5gc, component,
+			componentNetworks("upf", &instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec),
+			upfAddresses(upfConfig(free5gc, &instance, uplink))); err != nil {
+			return err
 		}
 
+		mountConfig(&deploy.Spec.Template, config)
+
 		return nil
 	})
 
@@ -756,21 +851,13 @@ func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *c
 			return err
 		}
 
+		svc.Labels = upfInstanceLabels(free5gc, instance.Name)
 		svc.Spec = corev1.ServiceSpec{
-			Selector: map[string]string{
-				"app":       "free5gc",
-				"component": "upf",
-				"instance":  instance.Name,
-			},
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "pfcp",
-					Protocol:   corev1.ProtocolUDP,
-					Port:       8805,
-					TargetPort: intstr.FromInt(8805),
-				},
-			},
+			Selector: upfInstanceLabels(free5gc, instance.Name),
+			Ports:    servicePorts(componentPorts(free5gc, "upf", &instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec)),
 		}
+		setIPFamilies(svc, free5gc)
+		exposeService(svc, componentExpose(&instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec))
 
 		return nil
 	})
@@ -780,279 +867,124 @@ func (r *Free5GCReconciler) reconcileUPFInstance(ctx context.Context, free5gc *c
 	}
 	log.Info("Reconciled UPF instance service", "instance", instance.Name, "operation", op)
 
-	return nil
+	return r.updateComponentStatus(ctx, free5gc, component,
+		componentNetworks("upf", &instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec))
 }
 
+// cleanupResources deletes the resources of a deleted Free5GC. Only the resources it
+// controls are deleted, as the instance label may also be set by other tools, such as
+// Helm, on resources of the same name sharing the namespace.
 func (r *Free5GCReconciler) cleanupResources(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
 	log := log.FromContext(ctx)
 
-	// Delete all deployments
-	if err := r.Client.DeleteAllOf(
-		ctx,
-		&appsv1.Deployment{},
-		client.InNamespace(free5gc.Namespace),
-		client.MatchingLabels(map[string]string{"app": "free5gc"}),
-		client.PropagationPolicy(metav1.DeletePropagationForeground),
-	); err != nil {
-		return fmt.Errorf("failed to cleanup deployments: %w", err)
-	}
-
-	// Delete all services
-	if err := r.Client.DeleteAllOf(
-		ctx,
-		&corev1.Service{},
-		client.InNamespace(free5gc.Namespace),
-		client.MatchingLabels(map[string]string{"app": "free5gc"}),
-		client.PropagationPolicy(metav1.DeletePropagationForeground),
-	); err != nil {
-		return fmt.Errorf("failed to cleanup services: %w", err)
-	}
-
-	// Delete MongoDB resources
-	if err := r.Client.DeleteAllOf(
-		ctx,
-		&appsv1.Deployment{},
-		client.InNamespace(free5gc.Namespace),
-		client.MatchingLabels(map[string]string{
-			"app":     "mongodb",
-			"free5gc": free5gc.Name,
-		}),
-		client.PropagationPolicy(metav1.DeletePropagationForeground),
-	); err != nil {
-		return fmt.Errorf("failed to cleanup mongodb deployment: %w", err)
-	}
-
-	if err := r.Client.DeleteAllOf(
-		ctx,
-		&corev1.Service{},
-		client.InNamespace(free5gc.Namespace),
-		client.MatchingLabels(map[string]string{
-			"app":     "mongodb",
-			"free5gc": free5gc.Name,
-		}),
-		client.PropagationPolicy(metav1.DeletePropagationForeground),
-	); err != nil {
-		return fmt.Errorf("failed to cleanup mongodb service: %w", err)
-	}
-
-	if err := r.Client.DeleteAllOf(
-		ctx,
-		&corev1.PersistentVolumeClaim{},
-		client.InNamespace(free5gc.Namespace),
-		client.MatchingLabels(map[string]string{
-			"app":     "mongodb",
-			"free5gc": free5gc.Name,
-		}),
-		client.PropagationPolicy(metav1.DeletePropagationForeground),
-	); err != nil {
-		return fmt.Errorf("failed to cleanup mongodb pvc: %w", err)
-	}
-
-	log.Info("Cleaned up all resources")
-	return nil
-}
-
-// SetupWithManager sets up the controller with the Manager.
-func (r *Free5GCReconciler) reconcileNSSF(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
-	log := log.FromContext(ctx)
-
-	if free5gc.Spec.NSSF == nil {
-		return nil
-	}
-
-	// Create ConfigMap for NSSF configuration
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-nssf-config", free5gc.Name),
-			Namespace: free5gc.Namespace,
-		},
-	}
-
-	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
-		if err := ctrl.SetControllerReference(free5gc, cm, r.Scheme); err != nil {
-			return err
-		}
-
-		config := free5gc.Spec.NSSF.Config
-		if config == nil {
-			config = &corev1alpha1.NSSFConfig{}
-		}
-
-		// Set default values if not provided
-		if config.SBI == nil {
-			config.SBI = &corev1alpha1.SBIConfig{
-				Scheme:      "http",
-				RegisterIPv4: fmt.Sprintf("%s-nssf-service", free5gc.Name),
-				BindingIPv4: "0.0.0.0",
-				Port:        80,
+	nads := &unstructured.UnstructuredList{}
+	nads.SetGroupVersionKind(networkAttachmentDefinitionGVK.GroupVersion().WithKind("NetworkAttachmentDefinitionList"))
+
+	for _, owned := range []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"Deployment", &appsv1.DeploymentList{}},
+		{"Service", &corev1.ServiceList{}},
+		{"ConfigMap", &corev1.ConfigMapList{}},
+		{"Secret", &corev1.SecretList{}},
+		{"Ingress", &networkingv1.IngressList{}},
+		{"NetworkAttachmentDefinition", nads},
+	} {
+		if err := r.List(ctx, owned.list, client.InNamespace(free5gc.Namespace),
+			client.MatchingLabels{instanceLabel: free5gc.Name}); err != nil {
+			// The network attachments cannot exist without Multus
+			if meta.IsNoMatchError(err) {
+				continue
 			}
+			return fmt.Errorf("failed to list %s resources: %w", owned.kind, err)
 		}
-
-		nssfConfig := map[string]interface{}{
-			"info": map[string]interface{}{
-				"version":     "1.0.2",
-				"description": "NSSF initial local configuration",
-			},
-			"configuration": map[string]interface{}{
-				"sbi": map[string]interface{}{
-					"scheme":       config.SBI.Scheme,
-					"registerIPv4": config.SBI.RegisterIPv4,
-					"bindingIPv4": config.SBI.BindingIPv4,
-					"port":        config.SBI.Port,
-				},
-				"serviceNameList": config.ServiceNameList,
-				"nrfUri":         config.NRFURI,
-				"nsiList":        config.NSIList,
-			},
-		}
-
-		configYaml, err := yaml.Marshal(nssfConfig)
+		objects, err := meta.ExtractList(owned.list)
 		if err != nil {
-			return fmt.Errorf("failed to marshal NSSF config: %w", err)
-		}
-
-		cm.Data = map[string]string{
-			"nssfcfg.yaml": string(configYaml),
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to reconcile NSSF ConfigMap: %w", err)
-	}
-	log.Info("Reconciled NSSF ConfigMap", "operation", op)
-
-	// Create NSSF deployment
-	deploy := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-nssf", free5gc.Name),
-			Namespace: free5gc.Namespace,
-		},
-	}
-
-	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, deploy, func() error {
-		if err := ctrl.SetControllerReference(free5gc, deploy, r.Scheme); err != nil {
 			return err
 		}
-
-		replicas := int32(1)
-		if free5gc.Spec.NSSF.Replicas != nil {
-			replicas = *free5gc.Spec.NSSF.Replicas
-		}
-
-		deploy.Spec = appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":       "free5gc",
-					"component": "nssf",
-				},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":       "free5gc",
-						"component": "nssf",
-					},
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:      "nssf",
-							Image:     free5gc.Spec.NSSF.Image,
-							Resources: free5gc.Spec.NSSF.Resources,
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      "config",
-									MountPath: "/free5gc/config",
-								},
-							},
-							Env: []corev1.EnvVar{
-								{
-									Name: "POD_IP",
-									ValueFrom: &corev1.EnvVarSource{
-										FieldRef: &corev1.ObjectFieldSelector{
-											FieldPath: "status.podIP",
-										},
-									},
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: "config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: fmt.Sprintf("%s-nssf-config", free5gc.Name),
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+		for _, item := range objects {
+			obj, ok := item.(client.Object)
+			if !ok || !metav1.IsControlledBy(obj, free5gc) {
+				continue
+			}
+			if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to cleanup %s %s: %w", owned.kind, obj.GetName(), err)
+			}
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to reconcile NSSF deployment: %w", err)
 	}
-	log.Info("Reconciled NSSF deployment", "operation", op)
 
-	// Create NSSF service
-	svc := &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-nssf-service", free5gc.Name),
-			Namespace: free5gc.Namespace,
-		},
+	if err := r.cleanupStorage(ctx, free5gc); err != nil {
+		return err
 	}
 
-	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
-		if err := ctrl.SetControllerReference(free5gc, svc, r.Scheme); err != nil {
-			return err
-		}
+	log.Info("Cleaned up all resources")
+	return nil
+}
 
-		svc.Spec = corev1.ServiceSpec{
-			Selector: map[string]string{
-				"app":       "free5gc",
-				"component": "nssf",
-			},
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "http",
-					Protocol:   corev1.ProtocolTCP,
-					Port:       80,
-					TargetPort: intstr.FromInt(80),
-				},
-			},
+// findFree5GCsForConfig returns a handler enqueuing the Free5GCs that mount files from
+// a ConfigMap or Secret referenced in configFrom, so that pods are rolled out on change
+func (r *Free5GCReconciler) findFree5GCsForConfig(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		free5gcs := &corev1alpha1.Free5GCList{}
+		if err := r.List(ctx, free5gcs,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{configFromIndex: configFromKey(kind, obj.GetName())},
+		); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list Free5GCs referencing configuration", "kind", kind, "name", obj.GetName())
+			return nil
 		}
 
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("failed to reconcile NSSF service: %w", err)
+		requests := make([]reconcile.Request, 0, len(free5gcs.Items))
+		for _, free5gc := range free5gcs.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: free5gc.Name, Namespace: free5gc.Namespace},
+			})
+		}
+		return requests
 	}
-	log.Info("Reconciled NSSF service", "operation", op)
+}
 
-	// Update NSSF status
-	if err := r.updateComponentStatus(ctx, free5gc, "nssf"); err != nil {
+// SetupWithManager sets up the controller with the Manager.
+func (r *Free5GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1alpha1.Free5GC{}, configFromIndex, func(obj client.Object) []string {
+		return configFromReferences(obj.(*corev1alpha1.Free5GC))
+	}); err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func (r *Free5GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1alpha1.Free5GC{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Secret{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
-		Complete(r)
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findFree5GCsForConfig("ConfigMap")),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findFree5GCsForConfig("Secret")),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		)
+
+	// The NetworkAttachmentDefinitions are only watched when Multus is installed, so that
+	// the manager starts without its CRD, for cores that attach no network
+	_, err := mgr.GetRESTMapper().RESTMapping(networkAttachmentDefinitionGVK.GroupKind(), networkAttachmentDefinitionGVK.Version)
+	switch {
+	case err == nil:
+		nad := &unstructured.Unstructured{}
+		nad.SetGroupVersionKind(networkAttachmentDefinitionGVK)
+		b = b.Owns(nad)
+	case meta.IsNoMatchError(err):
+		mgr.GetLogger().Info("NetworkAttachmentDefinitions are not watched, as the Multus CRD is not installed")
+	default:
+		return err
+	}
+
+	return b.Complete(r)
 }
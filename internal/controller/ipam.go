@@ -0,0 +1,268 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// addressedComponent is a component attached to networks, with the addresses it is
+// configured with by reference point
+type addressedComponent struct {
+	name       string
+	networks   []string
+	configured map[string]string
+	replicas   *int32
+}
+
+// addressedComponents returns the components that may be attached to networks. The
+// UPF addresses are taken from its settings rather than its effective configuration,
+// which is itself completed with the allocated addresses.
+func addressedComponents(free5gc *corev1alpha1.Free5GC) []addressedComponent {
+	var components []addressedComponent
+	for _, nf := range networkFunctions(free5gc) {
+		components = append(components, addressedComponent{
+			name:       nf.component,
+			networks:   componentNetworks(nf.component, nf.spec),
+			configured: componentAddresses(free5gc, nf.component),
+			replicas:   nf.spec.Replicas,
+		})
+	}
+	// The order of the components is kept stable, as it is the order of the allocations
+	for _, nf := range []struct {
+		name string
+		spec *corev1alpha1.ComponentSpec
+	}{
+		{"n3iwf", free5gc.Spec.N3IWF},
+		{"webui", free5gc.Spec.WebUI},
+	} {
+		if nf.spec != nil {
+			components = append(components, addressedComponent{
+				name:     nf.name,
+				networks: componentNetworks(nf.name, nf.spec),
+				replicas: nf.spec.Replicas,
+			})
+		}
+	}
+
+	upf := free5gc.Spec.UPF
+	if upf == nil {
+		return components
+	}
+	if upf.ULCL != nil && upf.ULCL.Enabled {
+		for i := range upf.ULCL.Instances {
+			instance := &upf.ULCL.Instances[i]
+			components = append(components, addressedComponent{
+				name:       upfComponent(instance),
+				networks:   componentNetworks("upf", &instance.ComponentSpec, &upf.ComponentSpec),
+				configured: upfAddresses(upfSettings(free5gc, instance)),
+				replicas:   instance.Replicas,
+			})
+		}
+		return components
+	}
+	return append(components, addressedComponent{
+		name:       "upf",
+		networks:   componentNetworks("upf", &upf.ComponentSpec),
+		configured: upfAddresses(upfSettings(free5gc, nil)),
+		replicas:   upf.Replicas,
+	})
+}
+
+// allocateAddresses allocates an address from the network CIDR to each component
+// attached to a network without an address of its own: neither configured for the
+// component nor set as the static IP of the network. The allocations recorded in the
+// status are kept while they remain valid, so that the addresses are stable; the other
+// addresses are the first ones of the CIDR that are not excluded, the gateway, or
+// assigned to another component. Components with several replicas get no address.
+func allocateAddresses(free5gc *corev1alpha1.Free5GC) ([]corev1alpha1.AddressAllocation, error) {
+	components := addressedComponents(free5gc)
+
+	// The addresses assigned outside the allocator on each network
+	used := map[string]map[netip.Addr]bool{}
+	for _, attachment := range networkAttachments(free5gc) {
+		used[attachment.refPoint] = map[netip.Addr]bool{}
+		for _, value := range []string{attachment.config.Gateway, attachment.config.StaticIP} {
+			if addr, err := netip.ParseAddr(value); err == nil {
+				used[attachment.refPoint][addr] = true
+			}
+		}
+	}
+	for _, component := range components {
+		for refPoint, value := range component.configured {
+			if addr, err := netip.ParseAddr(value); err == nil && used[refPoint] != nil {
+				used[refPoint][addr] = true
+			}
+		}
+	}
+
+	var wanted []corev1alpha1.AddressAllocation
+	for _, component := range components {
+		if component.replicas != nil && *component.replicas > 1 {
+			continue
+		}
+		for _, refPoint := range component.networks {
+			attachment := networkAttachmentFor(free5gc, refPoint)
+			if attachment == nil || attachment.config.CIDR == "" || attachment.config.StaticIP != "" ||
+				component.configured[refPoint] != "" {
+				continue
+			}
+			wanted = append(wanted, corev1alpha1.AddressAllocation{Component: component.name, Network: refPoint})
+		}
+	}
+
+	// Keep the recorded allocations first, so that new components do not take them
+	for i, allocation := range wanted {
+		for _, recorded := range free5gc.Status.Addresses {
+			if recorded.Component != allocation.Component || recorded.Network != allocation.Network {
+				continue
+			}
+			addr, err := netip.ParseAddr(recorded.Address)
+			if err == nil && !used[allocation.Network][addr] &&
+				allocatable(networkAttachmentFor(free5gc, allocation.Network).config, addr) {
+				wanted[i].Address = recorded.Address
+				used[allocation.Network][addr] = true
+			}
+		}
+	}
+
+	for i, allocation := range wanted {
+		if allocation.Address != "" {
+			continue
+		}
+		config := networkAttachmentFor(free5gc, allocation.Network).config
+		addr, err := nextAddress(config, used[allocation.Network])
+		if err != nil {
+			return nil, err
+		}
+		wanted[i].Address = addr.String()
+		used[allocation.Network][addr] = true
+	}
+	return wanted, nil
+}
+
+// allocatable reports whether an address of the CIDR of a network can be assigned to a
+// pod, leaving out the network and broadcast addresses and the excluded addresses
+func allocatable(config *corev1alpha1.NetworkAttachmentConfig, addr netip.Addr) bool {
+	prefix, err := netip.ParsePrefix(config.CIDR)
+	if err != nil {
+		return false
+	}
+	hosts := hostRange(prefix)
+	if addr.Less(hosts.start) || hosts.end.Less(addr) {
+		return false
+	}
+	if config.ExcludeIP != "" {
+		if excluded, err := parseAddressOrPrefix(config.ExcludeIP); err == nil && excluded.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// nextAddress returns the first address of the CIDR of a network that can be assigned
+// and is not used yet
+func nextAddress(config *corev1alpha1.NetworkAttachmentConfig, used map[netip.Addr]bool) (netip.Addr, error) {
+	prefix, err := netip.ParsePrefix(config.CIDR)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid CIDR of network %s: %w", config.Name, err)
+	}
+	hosts := hostRange(prefix)
+	for addr := hosts.start; addr.IsValid() && !hosts.end.Less(addr); addr = addr.Next() {
+		if !used[addr] && allocatable(config, addr) {
+			return addr, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("network %s has no address left to allocate in %s", config.Name, config.CIDR)
+}
+
+// allocatedAddress returns the address allocated to a component on the network of a
+// reference point, or an empty string when none is
+func allocatedAddress(free5gc *corev1alpha1.Free5GC, component, refPoint string) string {
+	for _, allocation := range free5gc.Status.Addresses {
+		if allocation.Component == component && allocation.Network == refPoint {
+			return allocation.Address
+		}
+	}
+	return ""
+}
+
+// reconcileAddresses allocates the addresses of the components and records them in the
+// status before the configurations and pods using them are rendered, so that they
+// survive the restarts of the operator
+func (r *Free5GCReconciler) reconcileAddresses(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
+	log := log.FromContext(ctx)
+
+	addresses, err := allocateAddresses(free5gc)
+	if err != nil {
+		return err
+	}
+	if slices.Equal(addresses, free5gc.Status.Addresses) {
+		return nil
+	}
+	free5gc.Status.Addresses = addresses
+	if err := r.Status().Update(ctx, free5gc); err != nil {
+		return fmt.Errorf("failed to record the allocated addresses: %w", err)
+	}
+	log.Info("Allocated network addresses", "addresses", len(addresses))
+	return nil
+}
+
+// addrRange is an inclusive range of IP addresses
+type addrRange struct {
+	start, end netip.Addr
+}
+
+// parseAddressOrPrefix parses an IP address, as a single address prefix, or a CIDR
+func parseAddressOrPrefix(value string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
+
+// lastAddr returns the last address of a prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(addr)*8; bit++ {
+		addr[bit/8] |= 0x80 >> (bit % 8)
+	}
+	last, _ := netip.AddrFromSlice(addr)
+	return last
+}
+
+// hostRange returns the addresses of a subnet that can be assigned to hosts, leaving
+// out the network and broadcast addresses
+func hostRange(prefix netip.Prefix) addrRange {
+	r := addrRange{start: prefix.Masked().Addr(), end: lastAddr(prefix)}
+	if prefix.Bits() < prefix.Addr().BitLen()-1 {
+		r.start = r.start.Next()
+		r.end = r.end.Prev()
+	}
+	return r
+}
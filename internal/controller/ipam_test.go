@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Address allocation", func() {
+	newFree5GC := func() *corev1alpha1.Free5GC {
+		return &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+			Spec: corev1alpha1.Free5GCSpec{
+				SMF: &corev1alpha1.SMFSpec{},
+				UPF: &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{
+					Enabled:   true,
+					Instances: []corev1alpha1.UPFInstance{{Name: "ulcl1"}, {Name: "ulcl2"}},
+				}},
+				Network: corev1alpha1.NetworkSpec{
+					N3Network: &corev1alpha1.NetworkAttachmentConfig{
+						Name: "n3-net", CIDR: "10.100.50.232/29", Gateway: "10.100.50.238", ExcludeIP: "10.100.50.233",
+					},
+					N4Network: &corev1alpha1.NetworkAttachmentConfig{Name: "n4-net", CIDR: "10.100.50.240/29"},
+					N9Network: &corev1alpha1.NetworkAttachmentConfig{Name: "n9-net", CIDR: "10.100.50.224/29"},
+				},
+			},
+		}
+	}
+
+	Context("When allocating the addresses of the components", func() {
+		It("should give each ULCL instance its own addresses on the shared networks", func() {
+			addresses, err := allocateAddresses(newFree5GC())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addresses).To(Equal([]corev1alpha1.AddressAllocation{
+				{Component: "smf", Network: "N4", Address: "10.100.50.241"},
+				{Component: "upf-ulcl1", Network: "N3", Address: "10.100.50.234"},
+				{Component: "upf-ulcl1", Network: "N4", Address: "10.100.50.242"},
+				{Component: "upf-ulcl1", Network: "N9", Address: "10.100.50.225"},
+				{Component: "upf-ulcl2", Network: "N3", Address: "10.100.50.235"},
+				{Component: "upf-ulcl2", Network: "N4", Address: "10.100.50.243"},
+				{Component: "upf-ulcl2", Network: "N9", Address: "10.100.50.226"},
+			}))
+		})
+
+		It("should not allocate the configured addresses and static IPs", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.Network.N4Network.StaticIP = "10.100.50.244"
+			free5gc.Spec.UPF.ULCL.Instances[0].UPFConfig = &corev1alpha1.UPFConfig{
+				GTPU: &corev1alpha1.GTPUConfig{IfList: []corev1alpha1.GTPUInterfaceConfig{{Addr: "10.100.50.234", Type: "N3"}}},
+			}
+			addresses, err := allocateAddresses(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addresses).NotTo(ContainElement(HaveField("Network", "N4")))
+			Expect(addresses).NotTo(ContainElement(corev1alpha1.AddressAllocation{
+				Component: "upf-ulcl1", Network: "N3", Address: "10.100.50.234",
+			}))
+			Expect(addresses).To(ContainElement(corev1alpha1.AddressAllocation{
+				Component: "upf-ulcl2", Network: "N3", Address: "10.100.50.235",
+			}))
+		})
+
+		It("should keep the recorded addresses that are still valid", func() {
+			free5gc := newFree5GC()
+			free5gc.Status.Addresses = []corev1alpha1.AddressAllocation{
+				{Component: "upf-ulcl2", Network: "N3", Address: "10.100.50.234"},
+				{Component: "upf-ulcl2", Network: "N4", Address: "10.100.50.100"},
+				{Component: "upf-removed", Network: "N3", Address: "10.100.50.236"},
+			}
+			addresses, err := allocateAddresses(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addresses).To(ContainElements(
+				corev1alpha1.AddressAllocation{Component: "upf-ulcl1", Network: "N3", Address: "10.100.50.235"},
+				corev1alpha1.AddressAllocation{Component: "upf-ulcl2", Network: "N3", Address: "10.100.50.234"},
+				corev1alpha1.AddressAllocation{Component: "upf-ulcl2", Network: "N4", Address: "10.100.50.243"},
+			))
+			Expect(addresses).NotTo(ContainElement(HaveField("Component", "upf-removed")))
+		})
+
+		It("should fail when the network has no address left", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.Network.N3Network.ExcludeIP = "10.100.50.232/29"
+			_, err := allocateAddresses(free5gc)
+			Expect(err).To(MatchError(ContainSubstring("no address left")))
+		})
+
+		It("should not allocate addresses to components with several replicas", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.NRF = &corev1alpha1.NRFSpec{ComponentSpec: corev1alpha1.ComponentSpec{
+				Replicas: ptr.To(int32(2)), Networks: []string{"N4"},
+			}}
+			addresses, err := allocateAddresses(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addresses).NotTo(ContainElement(HaveField("Component", "nrf")))
+		})
+	})
+
+	Context("When rendering the configurations", func() {
+		It("should bind the PFCP and GTP-U interfaces to the allocated addresses", func() {
+			free5gc := newFree5GC()
+			addresses, err := allocateAddresses(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			free5gc.Status.Addresses = addresses
+
+			config := upfConfig(free5gc, &free5gc.Spec.UPF.ULCL.Instances[0], true)
+			Expect(config.PFCP.Addr).To(Equal("10.100.50.242"))
+			Expect(config.GTPU.IfList).To(ConsistOf(
+				HaveField("Addr", "10.100.50.234"),
+				HaveField("Addr", "10.100.50.225"),
+			))
+
+			nodes := upfNodes(free5gc)
+			Expect(nodes[0].endpoints).To(HaveKeyWithValue("N3", "10.100.50.234"))
+			Expect(nodes[1].addr).To(Equal("10.100.50.243"))
+
+			rendered, err := buildSMFConfig(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			pfcp := rendered.defaults["configuration"].(map[string]interface{})["pfcp"].(corev1alpha1.SMFPFCPConfig)
+			Expect(pfcp.ListenAddr).To(Equal("10.100.50.241"))
+			Expect(pfcp.ExternalAddr).To(Equal("10.100.50.241"))
+		})
+	})
+
+	Context("When reconciling the addresses", func() {
+		It("should record the allocations in the status", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			free5gc := newFree5GC()
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(free5gc).WithStatusSubresource(free5gc).Build(),
+				Scheme: scheme,
+			}
+			Expect(reconciler.reconcileAddresses(context.Background(), free5gc)).To(Succeed())
+
+			stored := &corev1alpha1.Free5GC{}
+			Expect(reconciler.Get(context.Background(), types.NamespacedName{Name: "test-resource", Namespace: "default"}, stored)).To(Succeed())
+			Expect(stored.Status.Addresses).To(HaveLen(7))
+			Expect(stored.Status.Addresses).To(Equal(free5gc.Status.Addresses))
+		})
+	})
+})
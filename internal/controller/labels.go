@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// instanceLabel holds the name of the Free5GC owning a resource, so that the selectors
+// of several Free5GCs sharing a namespace do not select each other's pods
+const instanceLabel = "app.kubernetes.io/instance"
+
+// componentLabels returns the labels of the resources and pods of a component, which
+// the Deployment and Service of the component select its pods with
+func componentLabels(free5gc *corev1alpha1.Free5GC, component string) map[string]string {
+	return map[string]string{
+		"app":         "free5gc",
+		"component":   component,
+		instanceLabel: free5gc.Name,
+	}
+}
+
+// upfInstanceLabels returns the labels of the resources and pods of a ULCL instance
+func upfInstanceLabels(free5gc *corev1alpha1.Free5GC, instance string) map[string]string {
+	labels := componentLabels(free5gc, "upf")
+	labels["instance"] = instance
+	return labels
+}
+
+// mongoDBSelector returns the labels MongoDB pods are selected with. They were scoped
+// to the Free5GC from the start, so the selector is kept to spare recreating MongoDB.
+func mongoDBSelector(free5gc *corev1alpha1.Free5GC) map[string]string {
+	return map[string]string{
+		"app":     "mongodb",
+		"free5gc": free5gc.Name,
+	}
+}
+
+// mongoDBLabels returns the labels of the MongoDB resources and pods
+func mongoDBLabels(free5gc *corev1alpha1.Free5GC) map[string]string {
+	labels := mongoDBSelector(free5gc)
+	labels[instanceLabel] = free5gc.Name
+	return labels
+}
+
+// recreateForSelector deletes the Deployment of a Free5GC when its selector differs from
+// the selector it should have, as the selector of a Deployment cannot be changed. This
+// migrates the Deployments created before their selectors were scoped to the Free5GC.
+// It reports whether the Deployment is being deleted, in which case it is created again
+// on the reconciliation triggered by its deletion.
+func (r *Free5GCReconciler) recreateForSelector(ctx context.Context, free5gc *corev1alpha1.Free5GC, name string, selector map[string]string) (bool, error) {
+	log := log.FromContext(ctx)
+
+	deploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: free5gc.Namespace}, deploy); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get deployment %s: %w", name, err)
+	}
+	if !metav1.IsControlledBy(deploy, free5gc) ||
+		(deploy.Spec.Selector != nil && maps.Equal(deploy.Spec.Selector.MatchLabels, selector)) {
+		return false, nil
+	}
+	if !deploy.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+
+	if err := r.Delete(ctx, deploy, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete deployment %s to change its selector: %w", name, err)
+	}
+	log.Info("Deleted deployment to change its selector", "name", name)
+	r.Recorder.Eventf(free5gc, corev1.EventTypeNormal, "SelectorChanged", "Recreating Deployment %s to change its selector", name)
+	return true, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Instance labels", func() {
+	ctx := context.Background()
+
+	var (
+		scheme     *runtime.Scheme
+		recorder   *record.FakeRecorder
+		reconciler *Free5GCReconciler
+		core1      *corev1alpha1.Free5GC
+		core2      *corev1alpha1.Free5GC
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+		scheme.AddKnownTypeWithName(networkAttachmentDefinitionGVK, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(networkAttachmentDefinitionGVK.GroupVersion().WithKind("NetworkAttachmentDefinitionList"),
+			&unstructured.UnstructuredList{})
+		newFree5GC := func(name string) *corev1alpha1.Free5GC {
+			return &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name + "-uid")},
+				Spec:       corev1alpha1.Free5GCSpec{NRF: &corev1alpha1.NRFSpec{}},
+			}
+		}
+		core1, core2 = newFree5GC("core1"), newFree5GC("core2")
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &Free5GCReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(core1, core2).Build(),
+			Scheme:   scheme,
+			Recorder: recorder,
+		}
+	})
+
+	Context("When two Free5GCs share a namespace", func() {
+		It("should only select the pods of their own components", func() {
+			for _, free5gc := range []*corev1alpha1.Free5GC{core1, core2} {
+				Expect(reconciler.reconcileDeployment(ctx, free5gc, "nrf", &free5gc.Spec.NRF.ComponentSpec, nil)).To(Succeed())
+				Expect(reconciler.reconcileService(ctx, free5gc, "nrf", &free5gc.Spec.NRF.ComponentSpec)).To(Succeed())
+			}
+
+			deploy := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "core1-nrf", Namespace: "default"}, deploy)).To(Succeed())
+			Expect(deploy.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+				"app": "free5gc", "component": "nrf", instanceLabel: "core1",
+			}))
+			Expect(deploy.Spec.Template.Labels).To(HaveKeyWithValue(instanceLabel, "core1"))
+			Expect(deploy.Labels).To(HaveKeyWithValue(instanceLabel, "core1"))
+
+			svc := &corev1.Service{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "core2-nrf", Namespace: "default"}, svc)).To(Succeed())
+			Expect(svc.Spec.Selector).To(HaveKeyWithValue(instanceLabel, "core2"))
+			Expect(svc.Labels).To(HaveKeyWithValue(instanceLabel, "core2"))
+		})
+
+		It("should only clean up the resources of the deleted Free5GC", func() {
+			for _, free5gc := range []*corev1alpha1.Free5GC{core1, core2} {
+				Expect(reconciler.reconcileDeployment(ctx, free5gc, "nrf", &free5gc.Spec.NRF.ComponentSpec, nil)).To(Succeed())
+				Expect(reconciler.reconcileService(ctx, free5gc, "nrf", &free5gc.Spec.NRF.ComponentSpec)).To(Succeed())
+			}
+			// A resource of a Helm release sharing the name of the Free5GC
+			Expect(reconciler.Create(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "core1-web", Namespace: "default", Labels: map[string]string{instanceLabel: "core1"},
+			}})).To(Succeed())
+			Expect(reconciler.cleanupResources(ctx, core1)).To(Succeed())
+
+			exists := func(obj client.Object, name string) bool {
+				err := reconciler.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, obj)
+				if errors.IsNotFound(err) {
+					return false
+				}
+				Expect(err).NotTo(HaveOccurred())
+				return true
+			}
+			Expect(exists(&appsv1.Deployment{}, "core1-nrf")).To(BeFalse())
+			Expect(exists(&corev1.Service{}, "core1-nrf")).To(BeFalse())
+			Expect(exists(&appsv1.Deployment{}, "core2-nrf")).To(BeTrue())
+			Expect(exists(&corev1.Service{}, "core2-nrf")).To(BeTrue())
+			Expect(exists(&appsv1.Deployment{}, "core1-web")).To(BeTrue())
+		})
+	})
+
+	Context("When a Deployment was created with the unscoped selector", func() {
+		It("should recreate the Deployment with the scoped selector", func() {
+			legacy := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "core1-nrf", Namespace: "default"},
+				Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "free5gc", "component": "nrf"},
+				}},
+			}
+			Expect(ctrl.SetControllerReference(core1, legacy, scheme)).To(Succeed())
+			Expect(reconciler.Create(ctx, legacy)).To(Succeed())
+
+			key := types.NamespacedName{Name: "core1-nrf", Namespace: "default"}
+			Expect(reconciler.reconcileDeployment(ctx, core1, "nrf", &core1.Spec.NRF.ComponentSpec, nil)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, key, &appsv1.Deployment{}))).To(BeTrue())
+			Expect(<-recorder.Events).To(Equal("Normal SelectorChanged Recreating Deployment core1-nrf to change its selector"))
+
+			Expect(reconciler.reconcileDeployment(ctx, core1, "nrf", &core1.Spec.NRF.ComponentSpec, nil)).To(Succeed())
+			deploy := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, key, deploy)).To(Succeed())
+			Expect(deploy.Spec.Selector.MatchLabels).To(HaveKeyWithValue(instanceLabel, "core1"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
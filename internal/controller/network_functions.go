@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// networkFunction describes a network function whose configuration file is rendered by the operator
+type networkFunction struct {
+	// component is the name of the network function
+	component string
+	// spec is the deployment specification of the network function
+	spec *corev1alpha1.ComponentSpec
+	// render renders the configuration files by name
+	render func(*corev1alpha1.Free5GC) (map[string]renderedConfig, error)
+}
+
+// renderFile adapts the builder of the single configuration file of a network function
+func renderFile(configFile string, build func(*corev1alpha1.Free5GC) renderedConfig) func(*corev1alpha1.Free5GC) (map[string]renderedConfig, error) {
+	return func(free5gc *corev1alpha1.Free5GC) (map[string]renderedConfig, error) {
+		return map[string]renderedConfig{configFile: build(free5gc)}, nil
+	}
+}
+
+// networkFunctions returns the network functions with a rendered configuration that are
+// enabled in the spec, in the order in which they are reconciled. The SMF configuration
+// includes the user plane topology derived from the UPF specification.
+func networkFunctions(free5gc *corev1alpha1.Free5GC) []networkFunction {
+	var nfs []networkFunction
+	add := func(component string, spec *corev1alpha1.ComponentSpec, build func(*corev1alpha1.Free5GC) renderedConfig) {
+		nfs = append(nfs, networkFunction{
+			component: component,
+			spec:      spec,
+			render:    renderFile(component+"cfg.yaml", build),
+		})
+	}
+
+	if nrf := free5gc.Spec.NRF; nrf != nil {
+		add("nrf", &nrf.ComponentSpec, buildNRFConfig)
+	}
+	if udr := free5gc.Spec.UDR; udr != nil {
+		add("udr", &udr.ComponentSpec, buildUDRConfig)
+	}
+	if udm := free5gc.Spec.UDM; udm != nil {
+		add("udm", &udm.ComponentSpec, buildUDMConfig)
+	}
+	if ausf := free5gc.Spec.AUSF; ausf != nil {
+		add("ausf", &ausf.ComponentSpec, buildAUSFConfig)
+	}
+	if pcf := free5gc.Spec.PCF; pcf != nil {
+		add("pcf", &pcf.ComponentSpec, buildPCFConfig)
+	}
+	if nssf := free5gc.Spec.NSSF; nssf != nil {
+		add("nssf", &nssf.ComponentSpec, buildNSSFConfig)
+	}
+	if amf := free5gc.Spec.AMF; amf != nil {
+		add("amf", &amf.ComponentSpec, buildAMFConfig)
+	}
+	if smf := free5gc.Spec.SMF; smf != nil {
+		nfs = append(nfs, networkFunction{
+			component: "smf",
+			spec:      &smf.ComponentSpec,
+			render:    renderSMFConfig,
+		})
+	}
+	return nfs
+}
+
+// Helper function to reconcile a network function whose configuration file is
+// rendered by the operator: its ConfigMap, Deployment, Service and status.
+// The files provided by the user are mounted along with the rendered one.
+func (r *Free5GCReconciler) reconcileNetworkFunction(ctx context.Context, free5gc *corev1alpha1.Free5GC, nf networkFunction) error {
+	rendered, err := nf.render(free5gc)
+	if err != nil {
+		return fmt.Errorf("failed to render %s configuration: %w", nf.component, err)
+	}
+	config, err := r.reconcileConfigMap(ctx, free5gc, nf.component, nf.spec, rendered)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileDeployment(ctx, free5gc, nf.component, nf.spec, config); err != nil {
+		return err
+	}
+	if err := r.reconcileService(ctx, free5gc, nf.component, nf.spec); err != nil {
+		return err
+	}
+
+	return r.updateComponentStatus(ctx, free5gc, nf.component, componentNetworks(nf.component, nf.spec))
+}
+
+// Helper function to reconcile a component whose configuration files, if any, are
+// provided in the spec and mounted as is: the N3IWF and the WebUI
+func (r *Free5GCReconciler) reconcileProvidedComponent(ctx context.Context, free5gc *corev1alpha1.Free5GC, component string, spec *corev1alpha1.ComponentSpec) error {
+	var config *componentConfig
+	if len(spec.Config) > 0 || len(spec.ConfigFrom) > 0 {
+		var err error
+		if config, err = r.reconcileConfigMap(ctx, free5gc, component, spec, nil); err != nil {
+			return err
+		}
+	}
+	if err := r.reconcileDeployment(ctx, free5gc, component, spec, config); err != nil {
+		return err
+	}
+	if err := r.reconcileService(ctx, free5gc, component, spec); err != nil {
+		return err
+	}
+	if err := r.reconcileIngress(ctx, free5gc, component, spec); err != nil {
+		return err
+	}
+
+	return r.updateComponentStatus(ctx, free5gc, component, componentNetworks(component, spec))
+}
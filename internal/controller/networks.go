@@ -0,0 +1,395 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// networkAttachmentDefinitionGVK is the kind of the Multus network attachments. The
+// Multus client library is not used, so the attachments are handled as unstructured objects.
+var networkAttachmentDefinitionGVK = schema.GroupVersionKind{
+	Group:   "k8s.cni.cncf.io",
+	Version: "v1",
+	Kind:    "NetworkAttachmentDefinition",
+}
+
+// networksAnnotation selects the Multus networks attached to a pod
+const networksAnnotation = "k8s.v1.cni.cncf.io/networks"
+
+// networkStatusAnnotation reports the networks Multus attached to a pod
+const networkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+
+// cniVersion is the CNI specification version of the rendered network configurations
+const cniVersion = "0.3.1"
+
+// defaultCNIModes are the modes of the CNI plugins when the network does not set one
+var defaultCNIModes = map[string]string{
+	"macvlan": "bridge",
+	"ipvlan":  "l2",
+}
+
+// networkAttachment is the network of a 3GPP reference point, such as N3
+type networkAttachment struct {
+	refPoint string
+	config   *corev1alpha1.NetworkAttachmentConfig
+}
+
+// networkAttachments returns the networks set in the spec, ordered by reference point
+func networkAttachments(free5gc *corev1alpha1.Free5GC) []networkAttachment {
+	network := free5gc.Spec.Network
+	var attachments []networkAttachment
+	for _, attachment := range []networkAttachment{
+		{"N2", network.N2Network},
+		{"N3", network.N3Network},
+		{"N4", network.N4Network},
+		{"N6", network.N6Network},
+		{"N9", network.N9Network},
+		{"NWu", network.NWuNetwork},
+	} {
+		if attachment.config != nil {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments
+}
+
+// defaultNetworks are the reference points of the networks attached to the network
+// functions that do not set their own
+var defaultNetworks = map[string][]string{
+	"amf":   {"N2"},
+	"smf":   {"N4"},
+	"upf":   {"N3", "N4", "N6", "N9"},
+	"n3iwf": {"N2", "N3", "NWu"},
+}
+
+// componentNetworks returns the reference points of the networks attached to a network
+// function, taken from the first spec setting them or from the defaults of the network
+// function. ULCL instances pass the UPF spec after their own to inherit its networks.
+func componentNetworks(nf string, specs ...*corev1alpha1.ComponentSpec) []string {
+	for _, spec := range specs {
+		if spec != nil && len(spec.Networks) > 0 {
+			return spec.Networks
+		}
+	}
+	return defaultNetworks[nf]
+}
+
+// componentAddresses returns the addresses a control plane network function is
+// configured with by reference point: the first NGAP address of the AMF on N2 and
+// the PFCP listen address of the SMF on N4. Wildcard addresses are left out.
+func componentAddresses(free5gc *corev1alpha1.Free5GC, nf string) map[string]string {
+	addrs := map[string]string{}
+	switch nf {
+	case "amf":
+		if config := free5gc.Spec.AMF.AMFConfig; config != nil && len(config.NGAPIPList) > 0 && isAddress(config.NGAPIPList[0]) {
+			addrs["N2"] = config.NGAPIPList[0]
+		}
+	case "smf":
+		if config := free5gc.Spec.SMF.SMFConfig; config != nil && config.PFCP != nil && isAddress(config.PFCP.ListenAddr) {
+			addrs["N4"] = config.PFCP.ListenAddr
+		}
+	}
+	return addrs
+}
+
+// networkAttachmentFor returns the network of a reference point, or nil when it is not set
+func networkAttachmentFor(free5gc *corev1alpha1.Free5GC, refPoint string) *networkAttachment {
+	for _, attachment := range networkAttachments(free5gc) {
+		if attachment.refPoint == refPoint {
+			return &attachment
+		}
+	}
+	return nil
+}
+
+// interfaceName returns the name of the pod interface attached to a network
+func (a networkAttachment) interfaceName() string {
+	if a.config.Interface != "" {
+		return a.config.Interface
+	}
+	return strings.ToLower(a.refPoint)
+}
+
+// networkSelectionElement is an entry of the JSON network selection annotation of Multus
+type networkSelectionElement struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	MAC       string   `json:"mac,omitempty"`
+}
+
+// setNetworkSelection attaches the networks of reference points to the pods of a component.
+// addrs are the addresses the component is configured with by reference point, such as
+// the GTP-U address of a UPF. They take precedence over the addresses allocated to the
+// component, then over the static IP of the network. Reference points without network
+// are skipped, and the annotation is removed when no network is left.
+func setNetworkSelection(template *corev1.PodTemplateSpec, free5gc *corev1alpha1.Free5GC, component string, refPoints []string, addrs map[string]string) error {
+	var selection []networkSelectionElement
+	for _, refPoint := range refPoints {
+		attachment := networkAttachmentFor(free5gc, refPoint)
+		if attachment == nil {
+			continue
+		}
+		element := networkSelectionElement{
+			Name:      attachment.config.Name,
+			Interface: attachment.interfaceName(),
+			MAC:       attachment.config.MAC,
+		}
+		addr := addrs[refPoint]
+		if addr == "" {
+			addr = allocatedAddress(free5gc, component, refPoint)
+		}
+		ip, err := podAddress(attachment.config, addr)
+		if err != nil {
+			return err
+		}
+		if ip != "" {
+			element.IPs = []string{ip}
+		}
+		selection = append(selection, element)
+	}
+
+	if len(selection) == 0 {
+		delete(template.Annotations, networksAnnotation)
+		return nil
+	}
+	content, err := json.Marshal(selection)
+	if err != nil {
+		return err
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[networksAnnotation] = string(content)
+	return nil
+}
+
+// podAddress returns the address requested on a network, with the prefix length of the
+// network CIDR, or an empty string when neither addr nor the static IP of the network is set
+func podAddress(config *corev1alpha1.NetworkAttachmentConfig, addr string) (string, error) {
+	if addr == "" {
+		addr = config.StaticIP
+	}
+	if addr == "" {
+		return "", nil
+	}
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s on network %s: %w", addr, config.Name, err)
+	}
+	bits := ip.BitLen()
+	if prefix, err := netip.ParsePrefix(config.CIDR); err == nil && prefix.Contains(ip) {
+		bits = prefix.Bits()
+	}
+	return netip.PrefixFrom(ip, bits).String(), nil
+}
+
+// networkStatusElement is an entry of the network status annotation of Multus. The name
+// of a network is the namespaced name of its NetworkAttachmentDefinition.
+type networkStatusElement struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	MAC       string   `json:"mac,omitempty"`
+}
+
+// podNetworkStatus returns the interfaces of a pod on the networks of reference points, as
+// reported by Multus, and the reference points whose network is not attached to the pod
+func podNetworkStatus(pod *corev1.Pod, free5gc *corev1alpha1.Free5GC, refPoints []string) corev1alpha1.PodNetworkStatus {
+	status := corev1alpha1.PodNetworkStatus{Name: pod.Name}
+
+	// A missing or invalid annotation means that no network is attached
+	var attached []networkStatusElement
+	if content, ok := pod.Annotations[networkStatusAnnotation]; ok {
+		_ = json.Unmarshal([]byte(content), &attached)
+	}
+
+	for _, refPoint := range refPoints {
+		attachment := networkAttachmentFor(free5gc, refPoint)
+		if attachment == nil {
+			continue
+		}
+		index := slices.IndexFunc(attached, func(element networkStatusElement) bool {
+			return (element.Name == attachment.config.Name || element.Name == pod.Namespace+"/"+attachment.config.Name) &&
+				element.Interface == attachment.interfaceName()
+		})
+		if index < 0 {
+			status.MissingNetworks = append(status.MissingNetworks, refPoint)
+			continue
+		}
+		status.Networks = append(status.Networks, corev1alpha1.NetworkInterfaceStatus{
+			Network:   refPoint,
+			Interface: attached[index].Interface,
+			IPs:       attached[index].IPs,
+			MAC:       attached[index].MAC,
+		})
+	}
+	return status
+}
+
+// hasIPFamily reports whether the core uses an IP family. Cores without IP families
+// are IPv4 single-stack.
+func hasIPFamily(free5gc *corev1alpha1.Free5GC, family corev1.IPFamily) bool {
+	families := free5gc.Spec.Network.IPFamilies
+	if len(families) == 0 {
+		return family == corev1.IPv4Protocol
+	}
+	return slices.Contains(families, family)
+}
+
+// wildcardAddr returns the address binding every interface in the primary IP family of
+// the core. The IPv6 wildcard address also accepts IPv4 connections on dual-stack nodes.
+func wildcardAddr(free5gc *corev1alpha1.Free5GC) string {
+	if families := free5gc.Spec.Network.IPFamilies; len(families) > 0 && families[0] == corev1.IPv6Protocol {
+		return "::"
+	}
+	return "0.0.0.0"
+}
+
+// setIPFamilies sets the IP families of a Service to the IP families of the core. The
+// Service keeps the cluster defaults when the core does not set them.
+func setIPFamilies(svc *corev1.Service, free5gc *corev1alpha1.Free5GC) {
+	families := free5gc.Spec.Network.IPFamilies
+	if len(families) == 0 {
+		return
+	}
+	policy := corev1.IPFamilyPolicySingleStack
+	if len(families) > 1 {
+		policy = corev1.IPFamilyPolicyRequireDualStack
+	}
+	svc.Spec.IPFamilies = slices.Clone(families)
+	svc.Spec.IPFamilyPolicy = &policy
+}
+
+// isManagedNetwork reports whether the operator creates the NetworkAttachmentDefinition of
+// a network. Networks without a CNI type refer to a NetworkAttachmentDefinition managed
+// outside the operator.
+func isManagedNetwork(config *corev1alpha1.NetworkAttachmentConfig) bool {
+	return config.Type != ""
+}
+
+// networkConfig renders the CNI configuration of the network of a reference point. The
+// plugin assigns the addresses and MAC addresses requested by the pods: the addresses are
+// allocated by the operator from the CIDR of the network, see allocateAddresses.
+func networkConfig(refPoint string, config *corev1alpha1.NetworkAttachmentConfig) (string, error) {
+	mode := config.Mode
+	if mode == "" {
+		mode = defaultCNIModes[config.Type]
+	}
+	// ipvlan interfaces share the MAC address of the master interface
+	capabilities := map[string]bool{"ips": true}
+	if config.Type == "macvlan" {
+		capabilities["mac"] = true
+	}
+	plugin := map[string]interface{}{
+		"cniVersion":   cniVersion,
+		"name":         config.Name,
+		"type":         config.Type,
+		"mode":         mode,
+		"capabilities": capabilities,
+	}
+	if config.MasterInterface != "" {
+		plugin["master"] = config.MasterInterface
+	}
+	ipam, err := networkIPAM(refPoint, config)
+	if err != nil {
+		return "", err
+	}
+	plugin["ipam"] = ipam
+
+	content, err := json.Marshal(plugin)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// networkIPAM renders the static IPAM configuration of a network. The data networks are
+// reached through the gateway of the N6 network, which is the default route of the pods
+// on N6, so that the UPF sends the uplink traffic to the data networks.
+func networkIPAM(refPoint string, config *corev1alpha1.NetworkAttachmentConfig) (map[string]interface{}, error) {
+	ipam := map[string]interface{}{"type": "static"}
+	if refPoint != "N6" || config.Gateway == "" {
+		return ipam, nil
+	}
+	gateway, err := netip.ParseAddr(config.Gateway)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway of network %s: %w", config.Name, err)
+	}
+	dst := "0.0.0.0/0"
+	if gateway.Is6() {
+		dst = "::/0"
+	}
+	ipam["routes"] = []map[string]string{{"dst": dst, "gw": gateway.String()}}
+	return ipam, nil
+}
+
+// reconcileNetworkAttachments creates or updates the NetworkAttachmentDefinitions of the
+// networks managed by the operator, so that they follow the network configuration
+func (r *Free5GCReconciler) reconcileNetworkAttachments(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
+	log := log.FromContext(ctx)
+
+	for _, attachment := range networkAttachments(free5gc) {
+		if !isManagedNetwork(attachment.config) {
+			continue
+		}
+		config, err := networkConfig(attachment.refPoint, attachment.config)
+		if err != nil {
+			return err
+		}
+
+		nad := &unstructured.Unstructured{}
+		nad.SetGroupVersionKind(networkAttachmentDefinitionGVK)
+		nad.SetName(attachment.config.Name)
+		nad.SetNamespace(free5gc.Namespace)
+
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, nad, func() error {
+			if err := ctrl.SetControllerReference(free5gc, nad, r.Scheme); err != nil {
+				return err
+			}
+			labels := nad.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels["app"] = "free5gc"
+			labels["free5gc"] = free5gc.Name
+			labels[instanceLabel] = free5gc.Name
+			nad.SetLabels(labels)
+			return unstructured.SetNestedField(nad.Object, config, "spec", "config")
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile %s network attachment %s: %w", attachment.refPoint, attachment.config.Name, err)
+		}
+		log.Info("Reconciled network attachment", "network", attachment.refPoint, "name", attachment.config.Name, "operation", op)
+	}
+	return nil
+}
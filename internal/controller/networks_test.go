@@ -0,0 +1,272 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Network attachments", func() {
+	n3 := func() *corev1alpha1.NetworkAttachmentConfig {
+		return &corev1alpha1.NetworkAttachmentConfig{
+			Name:            "n3-net",
+			Type:            "macvlan",
+			MasterInterface: "eth1",
+			CIDR:            "10.100.50.0/24",
+			Gateway:         "10.100.50.1",
+		}
+	}
+
+	render := func(config *corev1alpha1.NetworkAttachmentConfig) map[string]interface{} {
+		content, err := networkConfig("N3", config)
+		Expect(err).NotTo(HaveOccurred())
+		plugin := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(content), &plugin)).To(Succeed())
+		return plugin
+	}
+
+	Context("When rendering the CNI configuration of a network", func() {
+		It("should render the plugin with its default mode and static IPAM", func() {
+			plugin := render(n3())
+			Expect(plugin).To(HaveKeyWithValue("type", "macvlan"))
+			Expect(plugin).To(HaveKeyWithValue("mode", "bridge"))
+			Expect(plugin).To(HaveKeyWithValue("master", "eth1"))
+			Expect(plugin).To(HaveKeyWithValue("capabilities", Equal(map[string]interface{}{"ips": true, "mac": true})))
+			Expect(plugin["ipam"]).To(Equal(map[string]interface{}{"type": "static"}))
+		})
+
+		It("should share the MAC address of the master interface with ipvlan", func() {
+			config := n3()
+			config.Type = "ipvlan"
+			plugin := render(config)
+			Expect(plugin).To(HaveKeyWithValue("mode", "l2"))
+			Expect(plugin).To(HaveKeyWithValue("capabilities", Equal(map[string]interface{}{"ips": true})))
+		})
+
+		It("should route the data networks through the gateway of N6", func() {
+			config := &corev1alpha1.NetworkAttachmentConfig{
+				Name: "n6-net", Type: "ipvlan", MasterInterface: "eth2", CIDR: "10.100.100.0/24", Gateway: "10.100.100.1",
+			}
+			content, err := networkConfig("N6", config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring(`"ipam":{"routes":[{"dst":"0.0.0.0/0","gw":"10.100.100.1"}],"type":"static"}`))
+
+			config.Gateway = "2001:db8:100::1"
+			content, err = networkConfig("N6", config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(ContainSubstring(`"routes":[{"dst":"::/0","gw":"2001:db8:100::1"}]`))
+		})
+	})
+
+	Context("When attaching the networks to pods", func() {
+		selection := func(template *corev1.PodTemplateSpec) []map[string]interface{} {
+			var elements []map[string]interface{}
+			Expect(json.Unmarshal([]byte(template.Annotations[networksAnnotation]), &elements)).To(Succeed())
+			return elements
+		}
+
+		It("should select each network with its interface, address and MAC address", func() {
+			free5gc := &corev1alpha1.Free5GC{Spec: corev1alpha1.Free5GCSpec{Network: corev1alpha1.NetworkSpec{
+				N3Network: n3(),
+				N4Network: &corev1alpha1.NetworkAttachmentConfig{Name: "n4-net", StaticIP: "10.100.50.242", MAC: "02:00:00:00:00:04"},
+			}}}
+			template := &corev1.PodTemplateSpec{}
+			Expect(setNetworkSelection(template, free5gc, "upf", []string{"N3", "N4", "N9"},
+				map[string]string{"N3": "10.100.50.233"})).To(Succeed())
+			Expect(selection(template)).To(Equal([]map[string]interface{}{
+				{"name": "n3-net", "interface": "n3", "ips": []interface{}{"10.100.50.233/24"}},
+				{"name": "n4-net", "interface": "n4", "ips": []interface{}{"10.100.50.242/32"}, "mac": "02:00:00:00:00:04"},
+			}))
+		})
+
+		It("should request the address allocated to the component without configured address", func() {
+			free5gc := &corev1alpha1.Free5GC{
+				Spec: corev1alpha1.Free5GCSpec{Network: corev1alpha1.NetworkSpec{N3Network: n3()}},
+				Status: corev1alpha1.Free5GCStatus{Addresses: []corev1alpha1.AddressAllocation{
+					{Component: "upf-ulcl1", Network: "N3", Address: "10.100.50.2"},
+				}},
+			}
+			template := &corev1.PodTemplateSpec{}
+			Expect(setNetworkSelection(template, free5gc, "upf-ulcl1", []string{"N3"}, nil)).To(Succeed())
+			Expect(selection(template)[0]).To(HaveKeyWithValue("ips", []interface{}{"10.100.50.2/24"}))
+			Expect(setNetworkSelection(template, free5gc, "upf-ulcl1", []string{"N3"},
+				map[string]string{"N3": "10.100.50.3"})).To(Succeed())
+			Expect(selection(template)[0]).To(HaveKeyWithValue("ips", []interface{}{"10.100.50.3/24"}))
+		})
+
+		It("should remove the annotation when no network is attached", func() {
+			template := &corev1.PodTemplateSpec{}
+			template.Annotations = map[string]string{networksAnnotation: "n3-net"}
+			Expect(setNetworkSelection(template, &corev1alpha1.Free5GC{}, "upf", []string{"N3"}, nil)).To(Succeed())
+			Expect(template.Annotations).NotTo(HaveKey(networksAnnotation))
+		})
+
+		It("should attach the default networks of the network function unless overridden", func() {
+			Expect(componentNetworks("amf", &corev1alpha1.ComponentSpec{})).To(Equal([]string{"N2"}))
+			Expect(componentNetworks("n3iwf", nil)).To(Equal([]string{"N2", "N3", "NWu"}))
+			Expect(componentNetworks("nrf", &corev1alpha1.ComponentSpec{})).To(BeEmpty())
+
+			upf := &corev1alpha1.ComponentSpec{Networks: []string{"N4", "N9"}}
+			Expect(componentNetworks("upf", &corev1alpha1.ComponentSpec{}, upf)).To(Equal([]string{"N4", "N9"}))
+			Expect(componentNetworks("upf", &corev1alpha1.ComponentSpec{Networks: []string{"N3"}}, upf)).To(Equal([]string{"N3"}))
+		})
+
+		It("should request the NGAP address of the AMF", func() {
+			free5gc := &corev1alpha1.Free5GC{Spec: corev1alpha1.Free5GCSpec{
+				AMF: &corev1alpha1.AMFSpec{AMFConfig: &corev1alpha1.AMFConfig{NGAPIPList: []string{"10.100.50.249"}}},
+			}}
+			Expect(componentAddresses(free5gc, "amf")).To(Equal(map[string]string{"N2": "10.100.50.249"}))
+			free5gc.Spec.AMF.AMFConfig.NGAPIPList = []string{"0.0.0.0"}
+			Expect(componentAddresses(free5gc, "amf")).To(BeEmpty())
+		})
+
+		It("should request the GTP-U and PFCP addresses of the UPF", func() {
+			addrs := upfAddresses(corev1alpha1.UPFConfig{
+				PFCP: &corev1alpha1.PFCPConfig{Addr: "upf.free5gc.org"},
+				GTPU: &corev1alpha1.GTPUConfig{IfList: []corev1alpha1.GTPUInterfaceConfig{
+					{Addr: "10.100.50.233", Type: "N3"},
+					{Addr: "0.0.0.0", Type: "N9"},
+				}},
+			})
+			Expect(addrs).To(Equal(map[string]string{"N3": "10.100.50.233"}))
+		})
+	})
+
+	Context("When reporting the networks attached to pods", func() {
+		free5gc := &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+			Spec: corev1alpha1.Free5GCSpec{Network: corev1alpha1.NetworkSpec{
+				N3Network: n3(),
+				N4Network: &corev1alpha1.NetworkAttachmentConfig{Name: "n4-net"},
+			}},
+		}
+		newPod := func(name, networkStatus string) *corev1.Pod {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"app": "free5gc", "component": "upf"},
+			}}
+			if networkStatus != "" {
+				pod.Annotations = map[string]string{networkStatusAnnotation: networkStatus}
+			}
+			pod.Status.Phase = corev1.PodRunning
+			return pod
+		}
+		attached := `[{"name":"cbr0","interface":"eth0","ips":["10.244.0.5"],"default":true},
+			{"name":"default/n3-net","interface":"n3","ips":["10.100.50.2"],"mac":"02:00:00:00:00:03"}]`
+
+		It("should report the interfaces of the pod and the missing networks", func() {
+			status := podNetworkStatus(newPod("upf-0", attached), free5gc, []string{"N3", "N4", "N9"})
+			Expect(status).To(Equal(corev1alpha1.PodNetworkStatus{
+				Name: "upf-0",
+				Networks: []corev1alpha1.NetworkInterfaceStatus{
+					{Network: "N3", Interface: "n3", IPs: []string{"10.100.50.2"}, MAC: "02:00:00:00:00:03"},
+				},
+				MissingNetworks: []string{"N4"},
+			}))
+		})
+
+		It("should report the pods of the components and raise a condition on missing networks", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			deploy := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource-upf", Namespace: "default"},
+				Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "free5gc", "component": "upf"},
+				}},
+			}
+			pending := newPod("upf-2", "")
+			pending.Status.Phase = corev1.PodPending
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(deploy, newPod("upf-1", ""), newPod("upf-0", attached), pending).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.updateComponentStatus(context.Background(), free5gc, "upf", []string{"N3"})).To(Succeed())
+			pods := free5gc.Status.Components["upf"].Pods
+			Expect(pods).To(HaveLen(2))
+			Expect(pods[0].Name).To(Equal("upf-0"))
+			Expect(pods[0].MissingNetworks).To(BeEmpty())
+			Expect(pods[1].MissingNetworks).To(Equal([]string{"N3"}))
+
+			setNetworksAttachedCondition(free5gc)
+			condition := meta.FindStatusCondition(free5gc.Status.Conditions, corev1alpha1.ConditionNetworksAttached)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("upf-1 (N3)"))
+
+			free5gc.Status.Components["upf"] = corev1alpha1.ComponentStatus{Pods: pods[:1]}
+			setNetworksAttachedCondition(free5gc)
+			Expect(meta.IsStatusConditionTrue(free5gc.Status.Conditions, corev1alpha1.ConditionNetworksAttached)).To(BeTrue())
+		})
+	})
+
+	Context("When reconciling the network attachments", func() {
+		It("should only create the NetworkAttachmentDefinitions of networks with a CNI type", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(networkAttachmentDefinitionGVK, meta.RESTScopeNamespace)
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).Build(),
+				Scheme: scheme,
+			}
+
+			free5gc := &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default", UID: "uid"},
+				Spec: corev1alpha1.Free5GCSpec{Network: corev1alpha1.NetworkSpec{
+					N2Network: &corev1alpha1.NetworkAttachmentConfig{Name: "n2-net"},
+					N3Network: n3(),
+				}},
+			}
+			Expect(reconciler.reconcileNetworkAttachments(context.Background(), free5gc)).To(Succeed())
+
+			get := func(name string) (*unstructured.Unstructured, error) {
+				nad := &unstructured.Unstructured{}
+				nad.SetGroupVersionKind(networkAttachmentDefinitionGVK)
+				return nad, reconciler.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, nad)
+			}
+			nad, err := get("n3-net")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nad.GetOwnerReferences()).To(HaveLen(1))
+			Expect(nad.GetLabels()).To(HaveKeyWithValue("free5gc", "test-resource"))
+			config, _, _ := unstructured.NestedString(nad.Object, "spec", "config")
+			Expect(config).To(ContainSubstring(`"type":"macvlan"`))
+
+			_, err = get("n2-net")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
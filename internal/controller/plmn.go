@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+const (
+	// defaultAMFID is the AMF identifier of the free5gc sample configuration
+	defaultAMFID = "cafe00"
+	// accessType3GPP is the access type of the tracking areas served over NGAP
+	accessType3GPP = "3GPP_ACCESS"
+)
+
+// The PLMN of the core is rendered into the defaults of every network function
+// configuration, so that it is declared once and can still be overridden per network
+// function, by a configuration file or by the typed configuration.
+
+// homePLMNID returns the first PLMN ID of the core, or nil when no PLMN is set
+func homePLMNID(free5gc *corev1alpha1.Free5GC) *corev1alpha1.PLMNID {
+	if plmn := free5gc.Spec.PLMN; plmn != nil && len(plmn.PLMNIDs) > 0 {
+		return &plmn.PLMNIDs[0]
+	}
+	return nil
+}
+
+// plmnIDs returns the PLMN IDs of the core, or nil when no PLMN is set
+func plmnIDs(free5gc *corev1alpha1.Free5GC) []corev1alpha1.PLMNID {
+	if plmn := free5gc.Spec.PLMN; plmn != nil {
+		return plmn.PLMNIDs
+	}
+	return nil
+}
+
+// servedGUAMIs returns a GUAMI of the default AMF in every PLMN of the core
+func servedGUAMIs(plmn *corev1alpha1.PLMNSpec) []corev1alpha1.GUAMIConfig {
+	guamis := make([]corev1alpha1.GUAMIConfig, 0, len(plmn.PLMNIDs))
+	for _, plmnID := range plmn.PLMNIDs {
+		guamis = append(guamis, corev1alpha1.GUAMIConfig{PLMNID: plmnID, AMFID: defaultAMFID})
+	}
+	return guamis
+}
+
+// plmnSupport returns the AMF plmnSupportList entries of the PLMNs of the core:
+// every slice of the core is supported in every PLMN
+func plmnSupport(free5gc *corev1alpha1.Free5GC) []corev1alpha1.PLMNSupportConfig {
+	plmnIDs := plmnIDs(free5gc)
+	support := make([]corev1alpha1.PLMNSupportConfig, 0, len(plmnIDs))
+	for _, plmnID := range plmnIDs {
+		support = append(support, corev1alpha1.PLMNSupportConfig{
+			PLMNID:     plmnID,
+			SNSSAIList: sliceSNSSAIs(free5gc),
+		})
+	}
+	return support
+}
+
+// networkName returns the network name of the core completed with a default name
+func networkName(plmn *corev1alpha1.PLMNSpec, name corev1alpha1.NetworkNameConfig) *corev1alpha1.NetworkNameConfig {
+	if plmn.NetworkName != nil {
+		if plmn.NetworkName.Full != "" {
+			name.Full = plmn.NetworkName.Full
+		}
+		if plmn.NetworkName.Short != "" {
+			name.Short = plmn.NetworkName.Short
+		}
+	}
+	return &name
+}
+
+// nssfTAList returns the NSSF taList entries of the tracking areas of the core, in
+// which every slice of the core is supported
+func nssfTAList(free5gc *corev1alpha1.Free5GC) []map[string]interface{} {
+	plmn := free5gc.Spec.PLMN
+	if plmn == nil {
+		return nil
+	}
+	taList := make([]map[string]interface{}, 0, len(plmn.TAIs))
+	for _, tai := range plmn.TAIs {
+		taList = append(taList, map[string]interface{}{
+			"tai":                 tai,
+			"accessType":          accessType3GPP,
+			"supportedSnssaiList": sliceSNSSAIs(free5gc),
+		})
+	}
+	return taList
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// componentPort is a port a component listens on
+type componentPort struct {
+	name     string
+	protocol corev1.Protocol
+	// port is the port of the Service
+	port int32
+	// containerPort is the port the container listens on
+	containerPort int32
+}
+
+// sbiPort is the default SBI port of the control plane network functions
+const sbiPort = 8000
+
+// defaultPorts are the ports of the network functions, matching the free5gc defaults
+var defaultPorts = func() map[string][]componentPort {
+	sbi := componentPort{name: "sbi", protocol: corev1.ProtocolTCP, port: sbiPort}
+	pfcp := componentPort{name: "pfcp", protocol: corev1.ProtocolUDP, port: 8805}
+	return map[string][]componentPort{
+		"nrf":  {sbi},
+		"udr":  {sbi},
+		"udm":  {sbi},
+		"ausf": {sbi},
+		"pcf":  {sbi},
+		"nssf": {sbi},
+		"amf":  {sbi, {name: "ngap", protocol: corev1.ProtocolSCTP, port: 38412}},
+		"smf":  {sbi, pfcp},
+		"upf":  {pfcp, {name: "gtpu", protocol: corev1.ProtocolUDP, port: 2152}},
+		"n3iwf": {
+			{name: "ike", protocol: corev1.ProtocolUDP, port: 500},
+			{name: "nat-t", protocol: corev1.ProtocolUDP, port: 4500},
+		},
+		"webui": {{name: "http", protocol: corev1.ProtocolTCP, port: 5000}},
+	}
+}()
+
+// componentPorts returns the ports of a network function, overridden by the ports of
+// the first spec setting them. ULCL instances pass the UPF spec after their own to
+// inherit its ports. The SBI and NGAP ports set in the configuration take precedence,
+// and the container listens on them; the other ports are fixed by free5gc, so the
+// container keeps listening on the default port.
+func componentPorts(free5gc *corev1alpha1.Free5GC, nf string, specs ...*corev1alpha1.ComponentSpec) []componentPort {
+	var overrides []corev1alpha1.PortSpec
+	for _, spec := range specs {
+		if spec != nil && len(spec.Ports) > 0 {
+			overrides = spec.Ports
+			break
+		}
+	}
+
+	ports := make([]componentPort, 0, len(defaultPorts[nf]))
+	for _, port := range defaultPorts[nf] {
+		port.containerPort = port.port
+		for _, override := range overrides {
+			if override.Name == port.name {
+				port.port = override.Port
+			}
+		}
+		switch port.name {
+		case "sbi":
+			if sbi := typedSBIConfig(free5gc, nf); sbi != nil && sbi.Port != 0 {
+				port.port = sbi.Port
+			}
+			port.containerPort = port.port
+		case "ngap":
+			if amf := free5gc.Spec.AMF; amf != nil && amf.AMFConfig != nil && amf.AMFConfig.NGAPPort != 0 {
+				port.port = amf.AMFConfig.NGAPPort
+			}
+			port.containerPort = port.port
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// componentPortNumber returns the port of a network function with the given name, or
+// 0 when the network function has no such port
+func componentPortNumber(free5gc *corev1alpha1.Free5GC, nf, name string, specs ...*corev1alpha1.ComponentSpec) int32 {
+	for _, port := range componentPorts(free5gc, nf, specs...) {
+		if port.name == name {
+			return port.port
+		}
+	}
+	return 0
+}
+
+// typedSBIConfig returns the SBI configuration set in the typed configuration of a
+// network function, or nil
+func typedSBIConfig(free5gc *corev1alpha1.Free5GC, nf string) *corev1alpha1.SBIConfig {
+	spec := free5gc.Spec
+	switch {
+	case nf == "nrf" && spec.NRF != nil && spec.NRF.NRFConfig != nil:
+		return spec.NRF.NRFConfig.SBI
+	case nf == "udr" && spec.UDR != nil && spec.UDR.UDRConfig != nil:
+		return spec.UDR.UDRConfig.SBI
+	case nf == "udm" && spec.UDM != nil && spec.UDM.UDMConfig != nil:
+		return spec.UDM.UDMConfig.SBI
+	case nf == "ausf" && spec.AUSF != nil && spec.AUSF.AUSFConfig != nil:
+		return spec.AUSF.AUSFConfig.SBI
+	case nf == "pcf" && spec.PCF != nil && spec.PCF.PCFConfig != nil:
+		return spec.PCF.PCFConfig.SBI
+	case nf == "nssf" && spec.NSSF != nil && spec.NSSF.NSSFConfig != nil:
+		return spec.NSSF.NSSFConfig.SBI
+	case nf == "amf" && spec.AMF != nil && spec.AMF.AMFConfig != nil:
+		return spec.AMF.AMFConfig.SBI
+	case nf == "smf" && spec.SMF != nil && spec.SMF.SMFConfig != nil:
+		return spec.SMF.SMFConfig.SBI
+	}
+	return nil
+}
+
+// componentSpec returns the spec of a network function, or nil
+func componentSpec(free5gc *corev1alpha1.Free5GC, nf string) *corev1alpha1.ComponentSpec {
+	spec := free5gc.Spec
+	switch {
+	case nf == "nrf" && spec.NRF != nil:
+		return &spec.NRF.ComponentSpec
+	case nf == "udr" && spec.UDR != nil:
+		return &spec.UDR.ComponentSpec
+	case nf == "udm" && spec.UDM != nil:
+		return &spec.UDM.ComponentSpec
+	case nf == "ausf" && spec.AUSF != nil:
+		return &spec.AUSF.ComponentSpec
+	case nf == "pcf" && spec.PCF != nil:
+		return &spec.PCF.ComponentSpec
+	case nf == "nssf" && spec.NSSF != nil:
+		return &spec.NSSF.ComponentSpec
+	case nf == "amf" && spec.AMF != nil:
+		return &spec.AMF.ComponentSpec
+	case nf == "smf" && spec.SMF != nil:
+		return &spec.SMF.ComponentSpec
+	case nf == "upf" && spec.UPF != nil:
+		return &spec.UPF.ComponentSpec
+	case nf == "n3iwf":
+		return spec.N3IWF
+	case nf == "webui":
+		return spec.WebUI
+	}
+	return nil
+}
+
+// containerPorts returns the ports declared by the container of a component
+func containerPorts(ports []componentPort) []corev1.ContainerPort {
+	declared := make([]corev1.ContainerPort, 0, len(ports))
+	for _, port := range ports {
+		declared = append(declared, corev1.ContainerPort{
+			Name:          port.name,
+			ContainerPort: port.containerPort,
+			Protocol:      port.protocol,
+		})
+	}
+	return declared
+}
+
+// servicePorts returns the ports of the Service of a component, targeting the named
+// ports of its container
+func servicePorts(ports []componentPort) []corev1.ServicePort {
+	exposed := make([]corev1.ServicePort, 0, len(ports))
+	for _, port := range ports {
+		exposed = append(exposed, corev1.ServicePort{
+			Name:       port.name,
+			Protocol:   port.protocol,
+			Port:       port.port,
+			TargetPort: intstr.FromString(port.name),
+		})
+	}
+	return exposed
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Component ports", func() {
+	newFree5GC := func() *corev1alpha1.Free5GC {
+		return &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+			Spec: corev1alpha1.Free5GCSpec{
+				NRF: &corev1alpha1.NRFSpec{},
+				AMF: &corev1alpha1.AMFSpec{},
+				UPF: &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{
+					Instances: []corev1alpha1.UPFInstance{{Name: "ulcl1"}},
+				}},
+			},
+		}
+	}
+
+	Context("When resolving the ports of the network functions", func() {
+		It("should use the free5gc defaults", func() {
+			free5gc := newFree5GC()
+			Expect(componentPorts(free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec)).To(Equal([]componentPort{
+				{name: "sbi", protocol: corev1.ProtocolTCP, port: 8000, containerPort: 8000},
+				{name: "ngap", protocol: corev1.ProtocolSCTP, port: 38412, containerPort: 38412},
+			}))
+			Expect(componentPorts(free5gc, "webui", nil)).To(Equal([]componentPort{
+				{name: "http", protocol: corev1.ProtocolTCP, port: 5000, containerPort: 5000},
+			}))
+		})
+
+		It("should render the overridden SBI and NGAP ports into the configuration", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.NRF.Ports = []corev1alpha1.PortSpec{{Name: "sbi", Port: 29510}}
+			free5gc.Spec.AMF.Ports = []corev1alpha1.PortSpec{{Name: "ngap", Port: 38413}}
+
+			Expect(componentPorts(free5gc, "nrf", &free5gc.Spec.NRF.ComponentSpec)).To(Equal([]componentPort{
+				{name: "sbi", protocol: corev1.ProtocolTCP, port: 29510, containerPort: 29510},
+			}))
+			Expect(defaultNRFURI(free5gc)).To(Equal("http://test-resource-nrf:29510"))
+
+			defaults := buildAMFConfig(free5gc).defaults["configuration"].(map[string]interface{})
+			Expect(defaults).To(HaveKeyWithValue("ngapPort", BeNumerically("==", 38413)))
+			Expect(defaults).To(HaveKeyWithValue("nrfUri", "http://test-resource-nrf:29510"))
+		})
+
+		It("should keep the port set in the configuration", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.AMF.Ports = []corev1alpha1.PortSpec{{Name: "sbi", Port: 8001}}
+			free5gc.Spec.AMF.AMFConfig = &corev1alpha1.AMFConfig{SBI: &corev1alpha1.SBIConfig{Port: 8002}}
+			Expect(componentPortNumber(free5gc, "amf", "sbi", &free5gc.Spec.AMF.ComponentSpec)).To(BeNumerically("==", 8002))
+		})
+
+		It("should follow the sbi port when the configuration sets no port", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.NRF.NRFConfig = &corev1alpha1.NRFConfig{SBI: &corev1alpha1.SBIConfig{Scheme: "http"}}
+			Expect(componentPortNumber(free5gc, "nrf", "sbi", &free5gc.Spec.NRF.ComponentSpec)).To(BeNumerically("==", 8000))
+
+			free5gc.Spec.NRF.Ports = []corev1alpha1.PortSpec{{Name: "sbi", Port: 8001}}
+			Expect(componentPortNumber(free5gc, "nrf", "sbi", &free5gc.Spec.NRF.ComponentSpec)).To(BeNumerically("==", 8001))
+			merged, err := renderConfigFile("configuration:\n  MongoDBUrl: mongodb://mongodb:27017\n", buildNRFConfig(free5gc))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged["configuration"].(map[string]interface{})["sbi"]).To(HaveKeyWithValue("port", BeNumerically("==", 8001)))
+		})
+
+		It("should only change the Service port of the ports fixed by free5gc", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.UPF.Ports = []corev1alpha1.PortSpec{{Name: "gtpu", Port: 12152}}
+			instance := &free5gc.Spec.UPF.ULCL.Instances[0]
+			Expect(componentPorts(free5gc, "upf", &instance.ComponentSpec, &free5gc.Spec.UPF.ComponentSpec)).To(ContainElement(
+				componentPort{name: "gtpu", protocol: corev1.ProtocolUDP, port: 12152, containerPort: 2152},
+			))
+		})
+	})
+
+	Context("When reconciling a network function", func() {
+		It("should declare the container ports targeted by the Service", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			free5gc := newFree5GC()
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(free5gc).Build(),
+				Scheme: scheme,
+			}
+			ctx := context.Background()
+			Expect(reconciler.reconcileDeployment(ctx, free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec, nil)).To(Succeed())
+			Expect(reconciler.reconcileService(ctx, free5gc, "amf", &free5gc.Spec.AMF.ComponentSpec)).To(Succeed())
+
+			key := types.NamespacedName{Name: "test-resource-amf", Namespace: "default"}
+			deploy := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, key, deploy)).To(Succeed())
+			Expect(deploy.Spec.Template.Spec.Containers[0].Ports).To(ConsistOf(
+				corev1.ContainerPort{Name: "sbi", ContainerPort: 8000, Protocol: corev1.ProtocolTCP},
+				corev1.ContainerPort{Name: "ngap", ContainerPort: 38412, Protocol: corev1.ProtocolSCTP},
+			))
+			svc := &corev1.Service{}
+			Expect(reconciler.Get(ctx, key, svc)).To(Succeed())
+			Expect(svc.Spec.Ports).To(ConsistOf(
+				corev1.ServicePort{Name: "sbi", Protocol: corev1.ProtocolTCP, Port: 8000, TargetPort: intstr.FromString("sbi")},
+				corev1.ServicePort{Name: "ngap", Protocol: corev1.ProtocolSCTP, Port: 38412, TargetPort: intstr.FromString("ngap")},
+			))
+		})
+	})
+})
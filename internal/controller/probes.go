@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// The default probes give a component 150 seconds to start, then restart it after 30
+// seconds without answer and take it out of its Service after 15 seconds
+var (
+	defaultStartupProbe   = corev1.Probe{PeriodSeconds: 5, TimeoutSeconds: 3, FailureThreshold: 30, SuccessThreshold: 1}
+	defaultLivenessProbe  = corev1.Probe{PeriodSeconds: 10, TimeoutSeconds: 3, FailureThreshold: 3, SuccessThreshold: 1}
+	defaultReadinessProbe = corev1.Probe{PeriodSeconds: 5, TimeoutSeconds: 3, FailureThreshold: 3, SuccessThreshold: 1}
+)
+
+// mongoDBPing pings MongoDB with the shell of the image: mongosh from MongoDB 5, or the
+// legacy mongo shell
+const mongoDBPing = `mongosh --quiet --eval 'db.adminCommand("ping")' 2>/dev/null || mongo --quiet --eval 'db.adminCommand("ping")'`
+
+// componentProbes returns the probes of the first spec setting them, so that ULCL
+// instances inherit the probes of the UPF
+func componentProbes(specs ...*corev1alpha1.ComponentSpec) *corev1alpha1.ProbesSpec {
+	for _, spec := range specs {
+		if spec != nil && spec.Probes != nil {
+			return spec.Probes
+		}
+	}
+	return nil
+}
+
+// probeHandler returns how a network function is probed: the SBI of the control plane
+// network functions, with HTTP GET requests on the SBI path of the probes or a TCP
+// connection, the UDP port of the UPF and the N3IWF, found listening in the socket table
+// of the container as UDP cannot be probed, and the HTTP port of the WebUI
+func probeHandler(free5gc *corev1alpha1.Free5GC, nf string, ports []componentPort, probes *corev1alpha1.ProbesSpec) *corev1.ProbeHandler {
+	switch nf {
+	case "upf", "n3iwf":
+		for _, port := range ports {
+			if port.protocol == corev1.ProtocolUDP {
+				return &corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: udpListening(port.containerPort)}}
+			}
+		}
+		return nil
+	case "webui":
+		return &corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromString("http")}}
+	}
+
+	for _, port := range ports {
+		if port.name != "sbi" {
+			continue
+		}
+		if probes != nil && probes.SBIPath != "" {
+			scheme := corev1.URISchemeHTTP
+			if sbi := typedSBIConfig(free5gc, nf); sbi != nil && sbi.Scheme == "https" {
+				scheme = corev1.URISchemeHTTPS
+			}
+			return &corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+				Path: probes.SBIPath, Port: intstr.FromString("sbi"), Scheme: scheme,
+			}}
+		}
+		return &corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("sbi")}}
+	}
+	return nil
+}
+
+// udpListening returns the command checking that a UDP port is bound in the IPv4 or IPv6
+// socket table, where the local port is in hexadecimal
+func udpListening(port int32) []string {
+	return []string{"sh", "-c", fmt.Sprintf("grep -qi ':%04X ' /proc/net/udp /proc/net/udp6", port)}
+}
+
+// setProbes sets the startup, liveness and readiness probes of a container, using a
+// handler and the thresholds of the probes overriding the defaults
+func setProbes(container *corev1.Container, handler *corev1.ProbeHandler, probes *corev1alpha1.ProbesSpec) {
+	if handler == nil {
+		return
+	}
+	if probes == nil {
+		probes = &corev1alpha1.ProbesSpec{}
+	}
+	container.StartupProbe = probe(handler, defaultStartupProbe, probes.Startup)
+	container.LivenessProbe = probe(handler, defaultLivenessProbe, probes.Liveness)
+	container.ReadinessProbe = probe(handler, defaultReadinessProbe, probes.Readiness)
+}
+
+// probe returns a probe with a handler and the thresholds of a spec overriding the
+// defaults, or nil when the spec disables it
+func probe(handler *corev1.ProbeHandler, defaults corev1.Probe, spec *corev1alpha1.ProbeSpec) *corev1.Probe {
+	p := defaults.DeepCopy()
+	p.ProbeHandler = *handler.DeepCopy()
+	if spec == nil {
+		return p
+	}
+	if spec.Disabled {
+		return nil
+	}
+	for _, threshold := range []struct {
+		value  *int32
+		target *int32
+	}{
+		{spec.InitialDelaySeconds, &p.InitialDelaySeconds},
+		{spec.PeriodSeconds, &p.PeriodSeconds},
+		{spec.TimeoutSeconds, &p.TimeoutSeconds},
+		{spec.FailureThreshold, &p.FailureThreshold},
+		{spec.SuccessThreshold, &p.SuccessThreshold},
+	} {
+		if threshold.value != nil {
+			*threshold.target = *threshold.value
+		}
+	}
+	return p
+}
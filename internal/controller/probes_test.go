@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Probes", func() {
+	newFree5GC := func() *corev1alpha1.Free5GC {
+		return &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default"},
+			Spec: corev1alpha1.Free5GCSpec{
+				NRF: &corev1alpha1.NRFSpec{},
+				UPF: &corev1alpha1.UPFSpec{},
+			},
+		}
+	}
+
+	Context("When probing the network functions", func() {
+		It("should open a TCP connection to the SBI by default", func() {
+			free5gc := newFree5GC()
+			handler := probeHandler(free5gc, "nrf", componentPorts(free5gc, "nrf", nil), nil)
+			Expect(handler).To(Equal(&corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("sbi")}}))
+		})
+
+		It("should send HTTPS requests to the SBI path of an https SBI", func() {
+			free5gc := newFree5GC()
+			free5gc.Spec.NRF.NRFConfig = &corev1alpha1.NRFConfig{SBI: &corev1alpha1.SBIConfig{Scheme: "https"}}
+			probes := &corev1alpha1.ProbesSpec{SBIPath: "/nnrf-nfm/v1/nf-instances"}
+			handler := probeHandler(free5gc, "nrf", componentPorts(free5gc, "nrf", nil), probes)
+			Expect(handler.HTTPGet).To(Equal(&corev1.HTTPGetAction{
+				Path: "/nnrf-nfm/v1/nf-instances", Port: intstr.FromString("sbi"), Scheme: corev1.URISchemeHTTPS,
+			}))
+		})
+
+		It("should look for the PFCP socket of the UPF", func() {
+			free5gc := newFree5GC()
+			handler := probeHandler(free5gc, "upf", componentPorts(free5gc, "upf", nil), nil)
+			Expect(handler.Exec.Command).To(Equal([]string{"sh", "-c", "grep -qi ':2265 ' /proc/net/udp /proc/net/udp6"}))
+		})
+
+		It("should override the default thresholds and disable probes", func() {
+			container := &corev1.Container{}
+			setProbes(container, &corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("sbi")}},
+				&corev1alpha1.ProbesSpec{
+					Startup:  &corev1alpha1.ProbeSpec{FailureThreshold: ptr.To(int32(60))},
+					Liveness: &corev1alpha1.ProbeSpec{Disabled: true},
+				})
+			Expect(container.StartupProbe.FailureThreshold).To(BeNumerically("==", 60))
+			Expect(container.StartupProbe.PeriodSeconds).To(BeNumerically("==", 5))
+			Expect(container.LivenessProbe).To(BeNil())
+			Expect(container.ReadinessProbe.PeriodSeconds).To(BeNumerically("==", 5))
+			Expect(container.ReadinessProbe.TCPSocket).NotTo(BeNil())
+		})
+	})
+
+	Context("When reconciling the UPF", func() {
+		It("should probe the UPF and its ULCL instances", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			free5gc := newFree5GC()
+			free5gc.Spec.UPF.Probes = &corev1alpha1.ProbesSpec{Readiness: &corev1alpha1.ProbeSpec{PeriodSeconds: ptr.To(int32(2))}}
+			free5gc.Spec.UPF.ULCL = &corev1alpha1.ULCLSpec{Enabled: true, Instances: []corev1alpha1.UPFInstance{{Name: "ulcl1"}}}
+			reconciler := &Free5GCReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(free5gc).Build(),
+				Scheme: scheme,
+			}
+			Expect(reconciler.reconcileUPF(context.Background(), free5gc)).To(Succeed())
+
+			deploy := &appsv1.Deployment{}
+			Expect(reconciler.Get(context.Background(), types.NamespacedName{Name: "test-resource-upf-ulcl1", Namespace: "default"}, deploy)).To(Succeed())
+			container := deploy.Spec.Template.Spec.Containers[0]
+			Expect(container.ReadinessProbe.PeriodSeconds).To(BeNumerically("==", 2))
+			Expect(container.LivenessProbe.Exec).NotTo(BeNil())
+			Expect(container.StartupProbe.Exec).NotTo(BeNil())
+		})
+	})
+})
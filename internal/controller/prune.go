@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// desiredResources returns the names of the resources a Free5GC should own by kind,
+// including the resources of the components held back by their dependencies. The
+// MongoDB PersistentVolumeClaim is left out, as it is never pruned.
+func desiredResources(free5gc *corev1alpha1.Free5GC) map[string]map[string]bool {
+	desired := map[string]map[string]bool{
+		"Deployment":                  {},
+		"Service":                     {},
+		"ConfigMap":                   {},
+		"Secret":                      {},
+		"Ingress":                     {},
+		"NetworkAttachmentDefinition": {},
+	}
+	addComponent := func(component string, config bool) {
+		name := fmt.Sprintf("%s-%s", free5gc.Name, component)
+		desired["Deployment"][name] = true
+		desired["Service"][name] = true
+		if config {
+			desired["ConfigMap"][configMapName(free5gc, component)] = true
+			desired["Secret"][configMapName(free5gc, component)] = true
+		}
+	}
+
+	if componentEnabled(free5gc, "mongodb") {
+		addComponent("mongodb", false)
+	}
+	for _, nf := range networkFunctions(free5gc) {
+		addComponent(nf.component, true)
+	}
+	for _, component := range []string{"n3iwf", "webui"} {
+		spec := componentSpec(free5gc, component)
+		if spec == nil {
+			continue
+		}
+		addComponent(component, len(spec.Config) > 0 || len(spec.ConfigFrom) > 0)
+		if expose := componentExpose(spec); expose != nil && expose.Ingress != nil {
+			desired["Ingress"][fmt.Sprintf("%s-%s", free5gc.Name, component)] = true
+		}
+	}
+	if upf := free5gc.Spec.UPF; upf != nil {
+		if upf.ULCL != nil && upf.ULCL.Enabled {
+			for i := range upf.ULCL.Instances {
+				addComponent(upfComponent(&upf.ULCL.Instances[i]), true)
+			}
+		} else {
+			addComponent("upf", true)
+		}
+	}
+	for _, attachment := range networkAttachments(free5gc) {
+		if isManagedNetwork(attachment.config) {
+			desired["NetworkAttachmentDefinition"][attachment.config.Name] = true
+		}
+	}
+	return desired
+}
+
+// pruneResources deletes the resources controlled by a Free5GC that its spec no longer
+// asks for, such as the resources of a removed network function or ULCL instance, and
+// records each deletion as an Event. The status of the removed components is dropped.
+func (r *Free5GCReconciler) pruneResources(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
+	log := log.FromContext(ctx)
+
+	nads := &unstructured.UnstructuredList{}
+	nads.SetGroupVersionKind(networkAttachmentDefinitionGVK.GroupVersion().WithKind("NetworkAttachmentDefinitionList"))
+
+	desired := desiredResources(free5gc)
+	for _, owned := range []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"Deployment", &appsv1.DeploymentList{}},
+		{"Service", &corev1.ServiceList{}},
+		{"ConfigMap", &corev1.ConfigMapList{}},
+		{"Secret", &corev1.SecretList{}},
+		{"Ingress", &networkingv1.IngressList{}},
+		{"NetworkAttachmentDefinition", nads},
+	} {
+		if err := r.List(ctx, owned.list, client.InNamespace(free5gc.Namespace)); err != nil {
+			// The network attachments cannot exist without Multus
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to list %s resources: %w", owned.kind, err)
+		}
+		objects, err := meta.ExtractList(owned.list)
+		if err != nil {
+			return err
+		}
+		for _, item := range objects {
+			obj, ok := item.(client.Object)
+			if !ok || !metav1.IsControlledBy(obj, free5gc) || desired[owned.kind][obj.GetName()] ||
+				!obj.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+			if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to prune %s %s: %w", owned.kind, obj.GetName(), err)
+			}
+			log.Info("Pruned resource no longer in the spec", "kind", owned.kind, "name", obj.GetName())
+			r.Recorder.Eventf(free5gc, corev1.EventTypeNormal, "Pruned", "Deleted %s %s, no longer in the spec", owned.kind, obj.GetName())
+		}
+	}
+
+	for component := range free5gc.Status.Components {
+		if !desired["Deployment"][fmt.Sprintf("%s-%s", free5gc.Name, component)] {
+			delete(free5gc.Status.Components, component)
+		}
+	}
+	return nil
+}
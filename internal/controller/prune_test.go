@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("Pruning", func() {
+	ctx := context.Background()
+
+	Context("When components are removed from the spec", func() {
+		It("should delete their resources and record the deletions", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+			free5gc := &corev1alpha1.Free5GC{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default", UID: "free5gc-uid"},
+				Spec: corev1alpha1.Free5GCSpec{
+					UPF: &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{
+						Enabled:   true,
+						Instances: []corev1alpha1.UPFInstance{{Name: "ulcl1"}},
+					}},
+					WebUI: &corev1alpha1.ComponentSpec{},
+				},
+				Status: corev1alpha1.Free5GCStatus{Components: map[string]corev1alpha1.ComponentStatus{
+					"upf-ulcl1": {Phase: "Running"},
+					"upf-ulcl2": {Phase: "Running"},
+					"n3iwf":     {Phase: "Running"},
+				}},
+			}
+
+			// The resources of a removed ULCL instance, of the removed N3IWF, of the Ingress
+			// of the WebUI, and a Deployment not controlled by the Free5GC
+			var objects []client.Object
+			owned := func(obj client.Object) client.Object {
+				Expect(ctrl.SetControllerReference(free5gc, obj, scheme)).To(Succeed())
+				return obj
+			}
+			for _, name := range []string{"test-resource-upf-ulcl1", "test-resource-upf-ulcl2", "test-resource-n3iwf", "test-resource-webui"} {
+				objects = append(objects,
+					owned(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}),
+					owned(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}))
+			}
+			objects = append(objects,
+				owned(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-resource-upf-ulcl1-config", Namespace: "default"}}),
+				owned(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-resource-upf-ulcl2-config", Namespace: "default"}}),
+				owned(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-resource-n3iwf-config", Namespace: "default"}}),
+				owned(&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test-resource-webui", Namespace: "default"}}),
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-resource-other", Namespace: "default"}},
+			)
+
+			recorder := record.NewFakeRecorder(20)
+			reconciler := &Free5GCReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+				Scheme:   scheme,
+				Recorder: recorder,
+			}
+			Expect(reconciler.pruneResources(ctx, free5gc)).To(Succeed())
+
+			exists := func(obj client.Object, name string) bool {
+				err := reconciler.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, obj)
+				if errors.IsNotFound(err) {
+					return false
+				}
+				Expect(err).NotTo(HaveOccurred())
+				return true
+			}
+			Expect(exists(&appsv1.Deployment{}, "test-resource-upf-ulcl1")).To(BeTrue())
+			Expect(exists(&corev1.ConfigMap{}, "test-resource-upf-ulcl1-config")).To(BeTrue())
+			Expect(exists(&appsv1.Deployment{}, "test-resource-webui")).To(BeTrue())
+			Expect(exists(&appsv1.Deployment{}, "test-resource-other")).To(BeTrue())
+			Expect(exists(&appsv1.Deployment{}, "test-resource-upf-ulcl2")).To(BeFalse())
+			Expect(exists(&corev1.Service{}, "test-resource-upf-ulcl2")).To(BeFalse())
+			Expect(exists(&corev1.ConfigMap{}, "test-resource-upf-ulcl2-config")).To(BeFalse())
+			Expect(exists(&appsv1.Deployment{}, "test-resource-n3iwf")).To(BeFalse())
+			Expect(exists(&corev1.ConfigMap{}, "test-resource-n3iwf-config")).To(BeFalse())
+			Expect(exists(&networkingv1.Ingress{}, "test-resource-webui")).To(BeFalse())
+
+			Expect(recorder.Events).To(HaveLen(7))
+			Expect(<-recorder.Events).To(Equal("Normal Pruned Deleted Deployment test-resource-n3iwf, no longer in the spec"))
+			Expect(free5gc.Status.Components).To(HaveKey("upf-ulcl1"))
+			Expect(free5gc.Status.Components).NotTo(HaveKey("upf-ulcl2"))
+			Expect(free5gc.Status.Components).NotTo(HaveKey("n3iwf"))
+		})
+	})
+})
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// The slices of the core are rendered into the defaults of the network function
+// configurations, like the PLMN, so that adding a slice only takes one entry.
+
+// sliceSNSSAIs returns the S-NSSAIs of the slices of the core
+func sliceSNSSAIs(free5gc *corev1alpha1.Free5GC) []corev1alpha1.SNSSAIConfig {
+	snssais := make([]corev1alpha1.SNSSAIConfig, 0, len(free5gc.Spec.Slices))
+	for _, slice := range free5gc.Spec.Slices {
+		snssais = append(snssais, slice.SNSSAIConfig)
+	}
+	return snssais
+}
+
+// sliceDNNs returns the data networks reachable in the slices of the core
+func sliceDNNs(free5gc *corev1alpha1.Free5GC) []string {
+	var dnns []string
+	for _, slice := range free5gc.Spec.Slices {
+		for _, dnn := range slice.DNNs {
+			if !slices.Contains(dnns, dnn.DNN) {
+				dnns = append(dnns, dnn.DNN)
+			}
+		}
+	}
+	return dnns
+}
+
+// servesSlice reports whether a standard UPF, with an empty instance name, or a
+// ULCL instance serves a slice
+func servesSlice(slice corev1alpha1.SliceSpec, instance string) bool {
+	return instance == "" || len(slice.UPFs) == 0 || slices.Contains(slice.UPFs, instance)
+}
+
+// nssfNSIList returns a network slice instance registered in the NRF for every slice
+func nssfNSIList(free5gc *corev1alpha1.Free5GC, nrfURI string) []corev1alpha1.NSIConfig {
+	nsiList := make([]corev1alpha1.NSIConfig, 0, len(free5gc.Spec.Slices))
+	for i, slice := range free5gc.Spec.Slices {
+		snssai := slice.SNSSAIConfig
+		nsiList = append(nsiList, corev1alpha1.NSIConfig{
+			SNSSAI: &snssai,
+			NSIInformationList: []corev1alpha1.NSIInformation{{
+				NRFID: fmt.Sprintf("%s/nnrf-nfm/v1/nf-instances", nrfURI),
+				NSIID: fmt.Sprintf("%d", i+1),
+			}},
+		})
+	}
+	return nsiList
+}
+
+// nssfSupportedNSSAIs returns the NSSF supportedNssaiInPlmnList entries: every slice
+// is supported in every PLMN of the core
+func nssfSupportedNSSAIs(free5gc *corev1alpha1.Free5GC) []map[string]interface{} {
+	if len(free5gc.Spec.Slices) == 0 {
+		return nil
+	}
+	plmnIDs := plmnIDs(free5gc)
+	supported := make([]map[string]interface{}, 0, len(plmnIDs))
+	for _, plmnID := range plmnIDs {
+		supported = append(supported, map[string]interface{}{
+			"plmnId":              plmnID,
+			"supportedSnssaiList": sliceSNSSAIs(free5gc),
+		})
+	}
+	return supported
+}
+
+// smfSNSSAIInfos returns the SMF snssaiInfos entries of the slices of the core
+func smfSNSSAIInfos(free5gc *corev1alpha1.Free5GC) []corev1alpha1.SNSSAIInfoConfig {
+	infos := make([]corev1alpha1.SNSSAIInfoConfig, 0, len(free5gc.Spec.Slices))
+	for _, slice := range free5gc.Spec.Slices {
+		info := corev1alpha1.SNSSAIInfoConfig{SNSSAI: slice.SNSSAIConfig}
+		for _, dnn := range slice.DNNs {
+			info.DNNInfos = append(info.DNNInfos, corev1alpha1.DNNInfoConfig{DNN: dnn.DNN, DNS: dnn.DNS})
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// smfUEIPPools returns the UE IP pools of the data networks of the slices. A pool is
+// pinned to its anchor when a single anchor UPF serves the slice.
+func smfUEIPPools(free5gc *corev1alpha1.Free5GC) []corev1alpha1.UEIPPoolConfig {
+	var pools []corev1alpha1.UEIPPoolConfig
+	for _, slice := range free5gc.Spec.Slices {
+		var anchors []string
+		for _, node := range upfNodes(free5gc) {
+			if node.anchor && servesSlice(slice, node.instance) {
+				anchors = append(anchors, node.instance)
+			}
+		}
+
+		for _, dnn := range slice.DNNs {
+			cidrs := dnnCIDRs(dnn)
+			if len(cidrs) == 0 {
+				continue
+			}
+			pool := corev1alpha1.UEIPPoolConfig{DNN: dnn.DNN, Pools: cidrs}
+			if len(anchors) == 1 {
+				pool.UPF = anchors[0]
+			}
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+// upfDNNList returns the UPF dnnList entries of the slices served by a standard UPF,
+// or by a ULCL instance when instance is set
+func upfDNNList(free5gc *corev1alpha1.Free5GC, instance *corev1alpha1.UPFInstance) []corev1alpha1.UPFDNNConfig {
+	var name string
+	if instance != nil {
+		name = instance.Name
+	}
+
+	var dnnList []corev1alpha1.UPFDNNConfig
+	for _, slice := range free5gc.Spec.Slices {
+		if !servesSlice(slice, name) {
+			continue
+		}
+		for _, dnn := range slice.DNNs {
+			for _, cidr := range dnnCIDRs(dnn) {
+				entry := corev1alpha1.UPFDNNConfig{DNN: dnn.DNN, CIDR: cidr}
+				if !slices.Contains(dnnList, entry) {
+					dnnList = append(dnnList, entry)
+				}
+			}
+		}
+	}
+	return dnnList
+}
+
+// dnnCIDRs returns the UE IP pool CIDRs of a data network, the IPv4 pool first
+func dnnCIDRs(dnn corev1alpha1.SliceDNNSpec) []string {
+	var cidrs []string
+	for _, cidr := range []string{dnn.CIDR, dnn.IPv6CIDR} {
+		if cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// retainsStorage reports whether the MongoDB PersistentVolumeClaim outlives the Free5GC
+func retainsStorage(free5gc *corev1alpha1.Free5GC) bool {
+	return free5gc.Spec.MongoDB != nil && free5gc.Spec.MongoDB.Storage != nil &&
+		free5gc.Spec.MongoDB.Storage.RetentionPolicy == corev1alpha1.RetentionPolicyRetain
+}
+
+// isFree5GCReference reports whether an owner reference points to a Free5GC of the
+// given name, including an earlier Free5GC of that name that has since been deleted
+func isFree5GCReference(ref metav1.OwnerReference, name string) bool {
+	return ref.Kind == "Free5GC" && ref.Name == name &&
+		strings.HasPrefix(ref.APIVersion, corev1alpha1.GroupVersion.Group+"/")
+}
+
+// setStorageOwner sets the owner of the MongoDB PersistentVolumeClaim. The claim of a
+// retained storage has no owner, so that the garbage collector does not delete it with
+// the Free5GC, while the claim is controlled by the Free5GC otherwise. The references to
+// an earlier Free5GC of the same name are dropped, so that the claim it retained is
+// adopted, but not a claim controlled by another resource.
+func (r *Free5GCReconciler) setStorageOwner(free5gc *corev1alpha1.Free5GC, pvc *corev1.PersistentVolumeClaim) error {
+	var refs []metav1.OwnerReference
+	for _, ref := range pvc.OwnerReferences {
+		if !isFree5GCReference(ref, free5gc.Name) {
+			refs = append(refs, ref)
+		}
+	}
+	pvc.OwnerReferences = refs
+
+	if retainsStorage(free5gc) {
+		if owner := metav1.GetControllerOf(pvc); owner != nil {
+			return fmt.Errorf("persistent volume claim %s is controlled by %s %s", pvc.Name, owner.Kind, owner.Name)
+		}
+		return nil
+	}
+	return ctrl.SetControllerReference(free5gc, pvc, r.Scheme)
+}
+
+// cleanupStorage deletes the MongoDB PersistentVolumeClaim of a deleted Free5GC, or
+// orphans it when the storage is retained. The claim is orphaned here as well, as the
+// policy may have been changed to Retain after the claim was last reconciled.
+func (r *Free5GCReconciler) cleanupStorage(ctx context.Context, free5gc *corev1alpha1.Free5GC) error {
+	log := log.FromContext(ctx)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	key := client.ObjectKey{Name: fmt.Sprintf("%s-mongodb", free5gc.Name), Namespace: free5gc.Namespace}
+	if err := r.Get(ctx, key, pvc); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get mongodb pvc: %w", err)
+	}
+
+	if !retainsStorage(free5gc) {
+		if !metav1.IsControlledBy(pvc, free5gc) {
+			return nil
+		}
+		if err := r.Delete(ctx, pvc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to cleanup mongodb pvc: %w", err)
+		}
+		return nil
+	}
+
+	var refs []metav1.OwnerReference
+	for _, ref := range pvc.OwnerReferences {
+		if ref.UID != free5gc.UID {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) != len(pvc.OwnerReferences) {
+		pvc.OwnerReferences = refs
+		if err := r.Update(ctx, pvc); err != nil {
+			return fmt.Errorf("failed to orphan mongodb pvc: %w", err)
+		}
+	}
+	log.Info("Retained MongoDB PVC", "name", pvc.Name)
+	r.Recorder.Eventf(free5gc, corev1.EventTypeNormal, "StorageRetained",
+		"Retained PersistentVolumeClaim %s with the MongoDB data", pvc.Name)
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("MongoDB storage", func() {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-resource-mongodb", Namespace: "default"}
+
+	var (
+		recorder   *record.FakeRecorder
+		reconciler *Free5GCReconciler
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1alpha1.AddToScheme(scheme)).To(Succeed())
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &Free5GCReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Scheme:   scheme,
+			Recorder: recorder,
+		}
+	})
+
+	newFree5GC := func(uid types.UID, policy string) *corev1alpha1.Free5GC {
+		return &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-resource", Namespace: "default", UID: uid},
+			Spec: corev1alpha1.Free5GCSpec{MongoDB: &corev1alpha1.MongoDBSpec{
+				Storage: &corev1alpha1.StorageSpec{Size: "1Gi", RetentionPolicy: policy},
+			}},
+		}
+	}
+
+	Context("When the storage is retained", func() {
+		It("should keep the claim without owner and let a later Free5GC adopt it", func() {
+			free5gc := newFree5GC("first-uid", corev1alpha1.RetentionPolicyRetain)
+			Expect(reconciler.reconcileMongoDB(ctx, free5gc)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(reconciler.Get(ctx, key, pvc)).To(Succeed())
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+			Expect(pvc.Labels).To(HaveKeyWithValue(instanceLabel, "test-resource"))
+
+			Expect(reconciler.cleanupStorage(ctx, free5gc)).To(Succeed())
+			Expect(reconciler.Get(ctx, key, pvc)).To(Succeed())
+			Expect(<-recorder.Events).To(Equal("Normal StorageRetained Retained PersistentVolumeClaim test-resource-mongodb with the MongoDB data"))
+
+			// A later Free5GC of the same name adopts the claim, keeping its size
+			later := newFree5GC("second-uid", corev1alpha1.RetentionPolicyDelete)
+			later.Spec.MongoDB.Storage.Size = "512Mi"
+			Expect(reconciler.reconcileMongoDB(ctx, later)).To(Succeed())
+			Expect(reconciler.Get(ctx, key, pvc)).To(Succeed())
+			Expect(pvc.OwnerReferences).To(HaveLen(1))
+			Expect(pvc.OwnerReferences[0].UID).To(Equal(types.UID("second-uid")))
+			Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+
+			Expect(reconciler.cleanupStorage(ctx, later)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, key, pvc))).To(BeTrue())
+		})
+
+		It("should orphan a claim still owned by the Free5GC on deletion", func() {
+			free5gc := newFree5GC("first-uid", corev1alpha1.RetentionPolicyDelete)
+			Expect(reconciler.reconcileMongoDB(ctx, free5gc)).To(Succeed())
+
+			free5gc.Spec.MongoDB.Storage.RetentionPolicy = corev1alpha1.RetentionPolicyRetain
+			Expect(reconciler.cleanupStorage(ctx, free5gc)).To(Succeed())
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(reconciler.Get(ctx, key, pvc)).To(Succeed())
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+		})
+
+		It("should not take over a claim controlled by another resource", func() {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1", Kind: "StatefulSet", Name: "other", UID: "other-uid", Controller: ptr.To(true),
+					}},
+				},
+			}
+			Expect(reconciler.Create(ctx, pvc)).To(Succeed())
+			Expect(reconciler.reconcileMongoDB(ctx, newFree5GC("first-uid", corev1alpha1.RetentionPolicyRetain))).
+				To(MatchError(ContainSubstring("controlled by StatefulSet other")))
+		})
+	})
+})
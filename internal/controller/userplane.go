@@ -0,0 +1,431 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+const (
+	// anNodeName is the name of the access network node in the SMF user plane topology
+	anNodeName = "gNB1"
+)
+
+// upfNode describes a UPF deployed by the operator as seen by the SMF
+type upfNode struct {
+	// name is the key of the node in userplaneInformation.upNodes
+	name string
+	// instance is the ULCL instance name, empty for a standard UPF
+	instance string
+	// nodeID is the PFCP node ID of the UPF
+	nodeID string
+	// addr is the PFCP address of the UPF
+	addr string
+	// endpoints are the GTP-U addresses of the UPF by interface type. Interfaces
+	// bound to a wildcard address have no endpoint.
+	endpoints map[string]string
+	// service is the DNS name of the UPF Service, which also carries GTP-U traffic
+	service string
+	// uplink is true for the UPF connected to the access network
+	uplink bool
+	// anchor is true for PDU session anchors, which hold the UE IP pools
+	anchor bool
+}
+
+// upfComponent returns the component name of a standard UPF or of a ULCL instance
+func upfComponent(instance *corev1alpha1.UPFInstance) string {
+	if instance == nil {
+		return "upf"
+	}
+	return fmt.Sprintf("upf-%s", instance.Name)
+}
+
+// upfSettings returns the settings explicitly configured for a standard UPF, or
+// for a ULCL instance when instance is set. Instances inherit the settings of the
+// UPF that are not tied to a single node (timers, forwarder, DNN list) and get
+// their own PFCP and GTP-U addresses.
+func upfSettings(free5gc *corev1alpha1.Free5GC, instance *corev1alpha1.UPFInstance) corev1alpha1.UPFConfig {
+	base := &corev1alpha1.UPFConfig{}
+	if free5gc.Spec.UPF.UPFConfig != nil {
+		base = free5gc.Spec.UPF.UPFConfig
+	}
+	override := &corev1alpha1.UPFConfig{}
+	if instance != nil && instance.UPFConfig != nil {
+		override = instance.UPFConfig
+	}
+
+	pfcp := corev1alpha1.PFCPConfig{}
+	if base.PFCP != nil {
+		pfcp = *base.PFCP
+	}
+	if instance != nil {
+		pfcp.Addr = ""
+		pfcp.NodeID = ""
+	}
+	if o := override.PFCP; o != nil {
+		if o.Addr != "" {
+			pfcp.Addr = o.Addr
+		}
+		if o.NodeID != "" {
+			pfcp.NodeID = o.NodeID
+		}
+		if o.RetransTimeout != "" {
+			pfcp.RetransTimeout = o.RetransTimeout
+		}
+		if o.MaxRetrans != 0 {
+			pfcp.MaxRetrans = o.MaxRetrans
+		}
+	}
+
+	gtpu := corev1alpha1.GTPUConfig{}
+	if base.GTPU != nil {
+		gtpu = *base.GTPU
+	}
+	if instance != nil {
+		gtpu.IfList = nil
+	}
+	if o := override.GTPU; o != nil {
+		if o.Forwarder != "" {
+			gtpu.Forwarder = o.Forwarder
+		}
+		if o.IfName != "" {
+			gtpu.IfName = o.IfName
+		}
+		if len(o.IfList) > 0 {
+			gtpu.IfList = o.IfList
+		}
+	}
+	gtpu.IfList = withIfName(gtpu.IfList, gtpu.IfName)
+
+	dnnList := base.DNNList
+	if len(override.DNNList) > 0 {
+		dnnList = override.DNNList
+	}
+
+	return corev1alpha1.UPFConfig{
+		PFCP:    &pfcp,
+		GTPU:    &gtpu,
+		DNNList: dnnList,
+	}
+}
+
+// upfConfig returns the effective configuration of a standard UPF, or of a ULCL
+// instance when instance is set: its settings completed with the defaults. The PFCP
+// and GTP-U interfaces bound to a wildcard address are bound to the addresses allocated
+// to the UPF on the N4, N3 and N9 networks instead.
+func upfConfig(free5gc *corev1alpha1.Free5GC, instance *corev1alpha1.UPFInstance, uplink bool) corev1alpha1.UPFConfig {
+	config := upfSettings(free5gc, instance)
+	component := upfComponent(instance)
+
+	pfcp := config.PFCP
+	if pfcp.NodeID == "" {
+		pfcp.NodeID = fmt.Sprintf("%s-%s", free5gc.Name, component)
+	}
+	if addr := allocatedAddress(free5gc, component, "N4"); addr != "" && isUnspecified(pfcp.Addr) {
+		pfcp.Addr = addr
+	}
+	if pfcp.Addr == "" {
+		pfcp.Addr = wildcardAddr(free5gc)
+	}
+	if pfcp.RetransTimeout == "" {
+		pfcp.RetransTimeout = "1s"
+	}
+	if pfcp.MaxRetrans == 0 {
+		pfcp.MaxRetrans = 3
+	}
+
+	if len(config.DNNList) == 0 {
+		config.DNNList = upfDNNList(free5gc, instance)
+	}
+
+	gtpu := config.GTPU
+	if gtpu.Forwarder == "" {
+		gtpu.Forwarder = "gtp5g"
+	}
+	if len(gtpu.IfList) == 0 {
+		// The uplink classifier terminates N3 and forwards to the anchors over N9
+		var ifTypes []string
+		if uplink {
+			ifTypes = append(ifTypes, "N3")
+		}
+		if instance != nil && len(free5gc.Spec.UPF.ULCL.Instances) > 1 {
+			ifTypes = append(ifTypes, "N9")
+		}
+		for _, ifType := range ifTypes {
+			gtpu.IfList = append(gtpu.IfList, corev1alpha1.GTPUInterfaceConfig{Addr: wildcardAddr(free5gc), Type: ifType})
+		}
+		gtpu.IfList = withIfName(gtpu.IfList, gtpu.IfName)
+	}
+	for i, iface := range gtpu.IfList {
+		if addr := allocatedAddress(free5gc, component, iface.Type); addr != "" && isUnspecified(iface.Addr) {
+			gtpu.IfList[i].Addr = addr
+		}
+	}
+
+	return config
+}
+
+// upfAddresses returns the addresses a UPF is configured with by reference point: the
+// GTP-U addresses of its N3 and N9 interfaces and its PFCP address on N4. Wildcard
+// addresses and host names are left out.
+func upfAddresses(config corev1alpha1.UPFConfig) map[string]string {
+	addrs := map[string]string{}
+	for _, iface := range config.GTPU.IfList {
+		if isAddress(iface.Addr) {
+			addrs[iface.Type] = iface.Addr
+		}
+	}
+	if isAddress(config.PFCP.Addr) {
+		addrs["N4"] = config.PFCP.Addr
+	}
+	return addrs
+}
+
+// withIfName sets the interface name of the GTP-U interfaces that do not set one
+func withIfName(ifList []corev1alpha1.GTPUInterfaceConfig, ifName string) []corev1alpha1.GTPUInterfaceConfig {
+	if len(ifList) == 0 {
+		return nil
+	}
+	named := make([]corev1alpha1.GTPUInterfaceConfig, 0, len(ifList))
+	for _, iface := range ifList {
+		if iface.IfName == "" {
+			iface.IfName = ifName
+		}
+		named = append(named, iface)
+	}
+	return named
+}
+
+// isAddress reports whether addr is an IP address other than a wildcard address
+func isAddress(addr string) bool {
+	return !isUnspecified(addr) && net.ParseIP(addr) != nil
+}
+
+// isUnspecified reports whether addr is empty or a wildcard address
+func isUnspecified(addr string) bool {
+	ip := net.ParseIP(addr)
+	return addr == "" || (ip != nil && ip.IsUnspecified())
+}
+
+// reachableAddr returns addr unless it is empty or a wildcard address, in which
+// case the fallback (usually the Service name of the UPF) is returned.
+func reachableAddr(addr string, fallback string) string {
+	if isUnspecified(addr) {
+		return fallback
+	}
+	return addr
+}
+
+// upfNodes returns the UPFs deployed for a Free5GC instance. In ULCL mode the
+// first instance acts as the uplink classifier connected to the access network
+// and the remaining instances are PDU session anchors behind it.
+func upfNodes(free5gc *corev1alpha1.Free5GC) []upfNode {
+	upf := free5gc.Spec.UPF
+	if upf == nil {
+		return nil
+	}
+
+	newNode := func(name string, instance *corev1alpha1.UPFInstance, uplink, anchor bool) upfNode {
+		config := upfConfig(free5gc, instance, uplink)
+		node := upfNode{
+			name:      name,
+			nodeID:    config.PFCP.NodeID,
+			addr:      reachableAddr(config.PFCP.Addr, config.PFCP.NodeID),
+			endpoints: map[string]string{},
+			service:   fmt.Sprintf("%s-%s.%s.svc", free5gc.Name, upfComponent(instance), free5gc.Namespace),
+			uplink:    uplink,
+			anchor:    anchor,
+		}
+		if instance != nil {
+			node.instance = instance.Name
+		}
+		for _, iface := range config.GTPU.IfList {
+			if _, ok := node.endpoints[iface.Type]; !ok && !isUnspecified(iface.Addr) {
+				node.endpoints[iface.Type] = iface.Addr
+			}
+		}
+		return node
+	}
+
+	if upf.ULCL != nil && upf.ULCL.Enabled {
+		nodes := make([]upfNode, 0, len(upf.ULCL.Instances))
+		for i := range upf.ULCL.Instances {
+			instance := &upf.ULCL.Instances[i]
+			nodes = append(nodes, newNode(instance.Name, instance, i == 0, i > 0 || len(upf.ULCL.Instances) == 1))
+		}
+		return nodes
+	}
+
+	return []upfNode{newNode("UPF", nil, true, true)}
+}
+
+// endpoint returns the GTP-U address of the node for an interface type, or the DNS
+// name of the UPF Service when the interface has no address, as the Service exposes
+// the gtpu port. The SMF resolves endpoints that are not IP addresses.
+func (n upfNode) endpoint(ifType string) string {
+	if addr, ok := n.endpoints[ifType]; ok {
+		return addr
+	}
+	return n.service
+}
+
+// userplaneLinks returns the links between the access network and the UPFs
+func userplaneLinks(nodes []upfNode) []map[string]string {
+	links := []map[string]string{}
+	var uplink string
+	for _, node := range nodes {
+		if node.uplink {
+			uplink = node.name
+			links = append(links, map[string]string{"A": anNodeName, "B": node.name})
+		}
+	}
+	for _, node := range nodes {
+		if !node.uplink && uplink != "" {
+			links = append(links, map[string]string{"A": uplink, "B": node.name})
+		}
+	}
+	return links
+}
+
+// userplaneInformation renders the SMF userplaneInformation section from the UPFs
+// deployed by the operator, assigning the UE IP pools to the anchor UPFs.
+func userplaneInformation(free5gc *corev1alpha1.Free5GC, config *corev1alpha1.SMFConfig) (map[string]interface{}, error) {
+	nodes := upfNodes(free5gc)
+
+	var dnns []string
+	for _, info := range config.SNSSAIInfos {
+		for _, dnn := range info.DNNInfos {
+			dnns = append(dnns, dnn.DNN)
+		}
+	}
+
+	upNodes := map[string]interface{}{
+		anNodeName: map[string]interface{}{
+			"type": "AN",
+		},
+	}
+
+	anchors := 0
+	for _, node := range nodes {
+		if node.anchor {
+			anchors++
+		}
+	}
+
+	ulcl := len(nodes) > 1
+	for _, node := range nodes {
+		snssaiUpfInfos := []interface{}{}
+		for _, info := range config.SNSSAIInfos {
+			dnnUpfInfoList := []interface{}{}
+			for _, dnn := range info.DNNInfos {
+				dnnUpfInfo := map[string]interface{}{
+					"dnn": dnn.DNN,
+				}
+				if node.anchor {
+					pools, err := uePools(config, dnn.DNN, node.instance, anchors)
+					if err != nil {
+						return nil, err
+					}
+					if len(pools) > 0 {
+						dnnUpfInfo["pools"] = pools
+					}
+				}
+				dnnUpfInfoList = append(dnnUpfInfoList, dnnUpfInfo)
+			}
+			snssaiUpfInfos = append(snssaiUpfInfos, map[string]interface{}{
+				"sNssai":         info.SNSSAI,
+				"dnnUpfInfoList": dnnUpfInfoList,
+			})
+		}
+
+		var ifTypes []string
+		if node.uplink {
+			ifTypes = append(ifTypes, "N3")
+		}
+		if ulcl {
+			ifTypes = append(ifTypes, "N9")
+		}
+		interfaces := []interface{}{}
+		for _, ifType := range ifTypes {
+			interfaces = append(interfaces, map[string]interface{}{
+				"interfaceType":    ifType,
+				"endpoints":        []string{node.endpoint(ifType)},
+				"networkInstances": dnns,
+			})
+		}
+
+		upNodes[node.name] = map[string]interface{}{
+			"type":           "UPF",
+			"nodeID":         node.nodeID,
+			"addr":           node.addr,
+			"sNssaiUpfInfos": snssaiUpfInfos,
+			"interfaces":     interfaces,
+		}
+	}
+
+	return map[string]interface{}{
+		"upNodes": upNodes,
+		"links":   userplaneLinks(nodes),
+	}, nil
+}
+
+// uePools returns the UE IP pools of a DNN anchored on the given ULCL instance. Pools
+// cannot be shared between anchors, so they must be pinned to a UPF when there are several.
+func uePools(config *corev1alpha1.SMFConfig, dnn string, instance string, anchors int) ([]map[string]string, error) {
+	var pools []map[string]string
+	for _, pool := range config.UEIPPools {
+		if pool.DNN != dnn {
+			continue
+		}
+		if pool.UPF == "" && anchors > 1 {
+			return nil, fmt.Errorf("the UE IP pools of DNN %s must set upf, as they cannot be shared between %d anchor UPFs", dnn, anchors)
+		}
+		if pool.UPF != "" && pool.UPF != instance {
+			continue
+		}
+		for _, cidr := range pool.Pools {
+			pools = append(pools, map[string]string{"cidr": cidr})
+		}
+	}
+	return pools, nil
+}
+
+// ueRoutingInfo renders the uerouting.yaml ueRoutingInfo section: every group of UEs
+// is routed through the user plane topology, and optionally through specific paths.
+func ueRoutingInfo(free5gc *corev1alpha1.Free5GC, config *corev1alpha1.SMFConfig) map[string]interface{} {
+	links := userplaneLinks(upfNodes(free5gc))
+
+	info := map[string]interface{}{}
+	for _, routing := range config.UERouting {
+		specificPaths := make([]map[string]interface{}, 0, len(routing.SpecificPaths))
+		for _, path := range routing.SpecificPaths {
+			specificPaths = append(specificPaths, map[string]interface{}{
+				"dest": path.Dest,
+				"path": path.Path,
+			})
+		}
+		info[routing.Name] = map[string]interface{}{
+			"members":      routing.Members,
+			"topology":     links,
+			"specificPath": specificPaths,
+		}
+	}
+	return info
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+var _ = Describe("User plane rendering", func() {
+	snssai := corev1alpha1.SNSSAIConfig{SST: 1, SD: "010203"}
+
+	gtpu := func(ifList ...corev1alpha1.GTPUInterfaceConfig) *corev1alpha1.UPFConfig {
+		return &corev1alpha1.UPFConfig{GTPU: &corev1alpha1.GTPUConfig{IfList: ifList}}
+	}
+
+	newFree5GC := func(upf *corev1alpha1.UPFSpec, pools ...corev1alpha1.UEIPPoolConfig) *corev1alpha1.Free5GC {
+		return &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resource",
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.Free5GCSpec{
+				SMF: &corev1alpha1.SMFSpec{
+					SMFConfig: &corev1alpha1.SMFConfig{
+						SNSSAIInfos: []corev1alpha1.SNSSAIInfoConfig{{
+							SNSSAI:   snssai,
+							DNNInfos: []corev1alpha1.DNNInfoConfig{{DNN: "internet"}},
+						}},
+						UEIPPools: pools,
+					},
+				},
+				UPF: upf,
+			},
+		}
+	}
+
+	ulcl := func(instances ...corev1alpha1.UPFInstance) *corev1alpha1.UPFSpec {
+		return &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{Enabled: true, Instances: instances}}
+	}
+
+	Context("When rendering a standard UPF", func() {
+		free5gc := newFree5GC(&corev1alpha1.UPFSpec{
+			UPFConfig: gtpu(corev1alpha1.GTPUInterfaceConfig{Addr: "10.100.50.233", Type: "N3"}),
+		}, corev1alpha1.UEIPPoolConfig{DNN: "internet", Pools: []string{"10.60.0.0/16"}})
+
+		It("should connect a single anchor to the access network", func() {
+			nodes := upfNodes(free5gc)
+			Expect(nodes).To(HaveLen(1))
+			Expect(nodes[0].name).To(Equal("UPF"))
+			Expect(nodes[0].nodeID).To(Equal("test-resource-upf"))
+			Expect(nodes[0].addr).To(Equal("test-resource-upf"))
+			Expect(nodes[0].uplink).To(BeTrue())
+			Expect(nodes[0].anchor).To(BeTrue())
+
+			Expect(userplaneLinks(nodes)).To(ConsistOf(map[string]string{"A": anNodeName, "B": "UPF"}))
+		})
+
+		It("should advertise the N3 address and the UE IP pools", func() {
+			info, err := userplaneInformation(free5gc, free5gc.Spec.SMF.SMFConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			upf := info["upNodes"].(map[string]interface{})["UPF"].(map[string]interface{})
+			Expect(upf["interfaces"]).To(ConsistOf(HaveKeyWithValue("endpoints", ConsistOf("10.100.50.233"))))
+
+			infos := upf["sNssaiUpfInfos"].([]interface{})
+			dnnInfos := infos[0].(map[string]interface{})["dnnUpfInfoList"].([]interface{})
+			Expect(dnnInfos[0]).To(HaveKeyWithValue("pools", ConsistOf(map[string]string{"cidr": "10.60.0.0/16"})))
+		})
+
+		It("should fall back to the UPF Service without a GTP-U address", func() {
+			free5gc := newFree5GC(&corev1alpha1.UPFSpec{
+				UPFConfig: gtpu(corev1alpha1.GTPUInterfaceConfig{Addr: "0.0.0.0", Type: "N3"}),
+			})
+
+			info, err := userplaneInformation(free5gc, free5gc.Spec.SMF.SMFConfig)
+			Expect(err).NotTo(HaveOccurred())
+			upf := info["upNodes"].(map[string]interface{})["UPF"].(map[string]interface{})
+			Expect(upf["interfaces"]).To(ConsistOf(HaveKeyWithValue("endpoints", ConsistOf("test-resource-upf.default.svc"))))
+		})
+	})
+
+	Context("When rendering ULCL instances", func() {
+		upf1 := corev1alpha1.UPFInstance{Name: "upf1", UPFConfig: gtpu(
+			corev1alpha1.GTPUInterfaceConfig{Addr: "10.100.50.233", Type: "N3"},
+			corev1alpha1.GTPUInterfaceConfig{Addr: "10.100.50.132", Type: "N9"},
+		)}
+		upf2 := corev1alpha1.UPFInstance{Name: "upf2", UPFConfig: gtpu(
+			corev1alpha1.GTPUInterfaceConfig{Addr: "10.100.50.134", Type: "N9"},
+		)}
+		upf3 := corev1alpha1.UPFInstance{Name: "upf3", UPFConfig: gtpu(
+			corev1alpha1.GTPUInterfaceConfig{Addr: "10.100.50.136", Type: "N9"},
+		)}
+
+		It("should link the anchors behind the uplink instance", func() {
+			nodes := upfNodes(newFree5GC(ulcl(upf1, upf2, upf3)))
+			Expect(nodes).To(HaveLen(3))
+			Expect(nodes[0].uplink).To(BeTrue())
+			Expect(nodes[0].anchor).To(BeFalse())
+			Expect(nodes[1].anchor).To(BeTrue())
+			Expect(nodes[2].anchor).To(BeTrue())
+
+			Expect(userplaneLinks(nodes)).To(Equal([]map[string]string{
+				{"A": anNodeName, "B": "upf1"},
+				{"A": "upf1", "B": "upf2"},
+				{"A": "upf1", "B": "upf3"},
+			}))
+		})
+
+		It("should advertise the N3 and N9 endpoints of each instance", func() {
+			free5gc := newFree5GC(ulcl(upf1, upf2))
+
+			info, err := userplaneInformation(free5gc, free5gc.Spec.SMF.SMFConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			upNodes := info["upNodes"].(map[string]interface{})
+			Expect(upNodes["upf1"]).To(HaveKeyWithValue("interfaces", ConsistOf(
+				SatisfyAll(HaveKeyWithValue("interfaceType", "N3"), HaveKeyWithValue("endpoints", ConsistOf("10.100.50.233"))),
+				SatisfyAll(HaveKeyWithValue("interfaceType", "N9"), HaveKeyWithValue("endpoints", ConsistOf("10.100.50.132"))),
+			)))
+			Expect(upNodes["upf2"]).To(HaveKeyWithValue("interfaces", ConsistOf(
+				SatisfyAll(HaveKeyWithValue("interfaceType", "N9"), HaveKeyWithValue("endpoints", ConsistOf("10.100.50.134"))),
+			)))
+		})
+
+		It("should assign each pool to its anchor only", func() {
+			smfConfig := newFree5GC(nil,
+				corev1alpha1.UEIPPoolConfig{DNN: "internet", Pools: []string{"10.60.0.0/16"}, UPF: "upf2"},
+				corev1alpha1.UEIPPoolConfig{DNN: "internet", Pools: []string{"10.61.0.0/16"}, UPF: "upf3"},
+			).Spec.SMF.SMFConfig
+
+			pools, err := uePools(smfConfig, "internet", "upf2", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(ConsistOf(map[string]string{"cidr": "10.60.0.0/16"}))
+
+			pools, err = uePools(smfConfig, "internet", "upf3", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(ConsistOf(map[string]string{"cidr": "10.61.0.0/16"}))
+		})
+
+		It("should require pools to name their anchor when there are several", func() {
+			free5gc := newFree5GC(ulcl(upf1, upf2, upf3),
+				corev1alpha1.UEIPPoolConfig{DNN: "internet", Pools: []string{"10.60.0.0/16"}},
+			)
+
+			_, err := userplaneInformation(free5gc, free5gc.Spec.SMF.SMFConfig)
+			Expect(err).To(MatchError(ContainSubstring("must set upf")))
+		})
+
+		It("should render the UE routing groups over the topology", func() {
+			free5gc := newFree5GC(ulcl(upf1, upf2))
+			free5gc.Spec.SMF.SMFConfig.UERouting = []corev1alpha1.UERoutingConfig{{
+				Name:    "UE1",
+				Members: []string{"imsi-208930000000001"},
+				SpecificPaths: []corev1alpha1.SpecificPathConfig{{
+					Dest: "10.100.100.26/32",
+					Path: []string{"upf1", "upf2"},
+				}},
+			}}
+
+			rendered, err := renderSMFConfig(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(HaveKey("uerouting.yaml"))
+
+			merged, err := renderConfigFile("", rendered["uerouting.yaml"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged["info"]).To(HaveKeyWithValue("version", "1.0.7"))
+
+			group := merged["ueRoutingInfo"].(map[string]interface{})["UE1"].(map[string]interface{})
+			Expect(group["members"]).To(ConsistOf("imsi-208930000000001"))
+			Expect(group["topology"]).To(ConsistOf(
+				map[string]interface{}{"A": anNodeName, "B": "upf1"},
+				map[string]interface{}{"A": "upf1", "B": "upf2"},
+			))
+			Expect(group["specificPath"]).To(ConsistOf(map[string]interface{}{
+				"dest": "10.100.100.26/32",
+				"path": []interface{}{"upf1", "upf2"},
+			}))
+		})
+
+		It("should render a default UE routing file", func() {
+			free5gc := newFree5GC(ulcl(upf1, upf2))
+
+			rendered, err := renderSMFConfig(free5gc)
+			Expect(err).NotTo(HaveOccurred())
+
+			merged, err := renderConfigFile("", rendered["uerouting.yaml"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged).To(HaveKeyWithValue("info", HaveKeyWithValue("description", "Routing information for UE")))
+		})
+	})
+
+	Context("When computing the configuration of ULCL instances", func() {
+		free5gc := newFree5GC(&corev1alpha1.UPFSpec{
+			UPFConfig: &corev1alpha1.UPFConfig{
+				PFCP: &corev1alpha1.PFCPConfig{Addr: "10.100.50.241", NodeID: "upf.free5gc.org", RetransTimeout: "2s"},
+				GTPU: &corev1alpha1.GTPUConfig{
+					Forwarder: "gtp5g",
+					IfName:    "upfgtp",
+					IfList:    []corev1alpha1.GTPUInterfaceConfig{{Addr: "10.100.50.233", Type: "N3"}},
+				},
+				DNNList: []corev1alpha1.UPFDNNConfig{{DNN: "internet", CIDR: "10.60.0.0/16"}},
+			},
+			ULCL: &corev1alpha1.ULCLSpec{
+				Enabled: true,
+				Instances: []corev1alpha1.UPFInstance{
+					{Name: "upf1"},
+					{Name: "upf2", UPFConfig: &corev1alpha1.UPFConfig{
+						PFCP:    &corev1alpha1.PFCPConfig{MaxRetrans: 5},
+						DNNList: []corev1alpha1.UPFDNNConfig{{DNN: "internet", CIDR: "10.61.0.0/16"}},
+					}},
+				},
+			},
+		})
+		upf1 := &free5gc.Spec.UPF.ULCL.Instances[0]
+		upf2 := &free5gc.Spec.UPF.ULCL.Instances[1]
+
+		It("should inherit the settings that are not tied to a node", func() {
+			config := upfConfig(free5gc, upf1, true)
+			Expect(config.PFCP.RetransTimeout).To(Equal("2s"))
+			Expect(config.GTPU.Forwarder).To(Equal("gtp5g"))
+			Expect(config.DNNList).To(ConsistOf(corev1alpha1.UPFDNNConfig{DNN: "internet", CIDR: "10.60.0.0/16"}))
+		})
+
+		It("should not share the PFCP and GTP-U addresses of the UPF", func() {
+			config := upfConfig(free5gc, upf1, true)
+			Expect(config.PFCP.NodeID).To(Equal("test-resource-upf-upf1"))
+			Expect(config.PFCP.Addr).To(Equal("0.0.0.0"))
+			Expect(config.GTPU.IfList).NotTo(ContainElement(HaveField("Addr", "10.100.50.233")))
+		})
+
+		It("should let instances override the inherited settings", func() {
+			config := upfConfig(free5gc, upf2, false)
+			Expect(config.PFCP.RetransTimeout).To(Equal("2s"))
+			Expect(config.PFCP.MaxRetrans).To(BeEquivalentTo(5))
+			Expect(config.DNNList).To(ConsistOf(corev1alpha1.UPFDNNConfig{DNN: "internet", CIDR: "10.61.0.0/16"}))
+		})
+
+		It("should default the uplink instance to N3 and N9 interfaces", func() {
+			Expect(upfConfig(free5gc, upf1, true).GTPU.IfList).To(Equal([]corev1alpha1.GTPUInterfaceConfig{
+				{Addr: "0.0.0.0", Type: "N3", IfName: "upfgtp"},
+				{Addr: "0.0.0.0", Type: "N9", IfName: "upfgtp"},
+			}))
+			Expect(upfConfig(free5gc, upf2, false).GTPU.IfList).To(Equal([]corev1alpha1.GTPUInterfaceConfig{
+				{Addr: "0.0.0.0", Type: "N9", IfName: "upfgtp"},
+			}))
+		})
+
+		It("should default a single instance to an N3 interface", func() {
+			free5gc := newFree5GC(ulcl(corev1alpha1.UPFInstance{Name: "upf1"}))
+			instance := &free5gc.Spec.UPF.ULCL.Instances[0]
+
+			Expect(upfConfig(free5gc, instance, true).GTPU.IfList).To(ConsistOf(HaveField("Type", "N3")))
+		})
+	})
+})
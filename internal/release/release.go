@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package release describes the free5gc releases supported by the operator: the
+// images of their network functions and the schema of their configuration files.
+package release
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// DefaultVersion is the free5gc release deployed when no version is set
+	DefaultVersion = "v3.3.0"
+	// DefaultImageRegistry is the registry and repository prefix of the free5gc images
+	DefaultImageRegistry = "free5gc"
+)
+
+// Release describes a free5gc release
+type Release struct {
+	// ImageTag is the tag of the network function images of the release
+	ImageTag string
+	// MongoDBImage is the MongoDB image the release is tested with
+	MongoDBImage string
+	// ConfigVersions are the info.version of the configuration files, by file name
+	ConfigVersions map[string]string
+}
+
+// configVersions33 are the configuration schema versions introduced by free5gc v3.3.0
+var configVersions33 = map[string]string{
+	"nrfcfg.yaml":    "1.0.2",
+	"amfcfg.yaml":    "1.0.9",
+	"smfcfg.yaml":    "1.0.7",
+	"uerouting.yaml": "1.0.7",
+	"upfcfg.yaml":    "1.0.3",
+	"ausfcfg.yaml":   "1.0.3",
+	"udmcfg.yaml":    "1.0.3",
+	"udrcfg.yaml":    "1.0.2",
+	"pcfcfg.yaml":    "1.0.2",
+	"nssfcfg.yaml":   "1.0.2",
+}
+
+// releases is the release matrix, keyed by version. The Enum marker of the
+// Free5GCSpec Version field lists the same versions.
+var releases = map[string]Release{
+	"v3.3.0": {
+		ImageTag:       "v3.3.0",
+		MongoDBImage:   "mongo:4.4",
+		ConfigVersions: configVersions33,
+	},
+	"v3.4.0": {
+		ImageTag:       "v3.4.0",
+		MongoDBImage:   "mongo:4.4",
+		ConfigVersions: configVersions33,
+	},
+}
+
+// Lookup returns the release with the given version, or the default release when
+// version is empty
+func Lookup(version string) (Release, error) {
+	if version == "" {
+		version = DefaultVersion
+	}
+	r, ok := releases[version]
+	if !ok {
+		return Release{}, fmt.Errorf("unsupported free5gc version %s, supported versions are %v", version, Versions())
+	}
+	return r, nil
+}
+
+// Versions returns the supported free5gc versions
+func Versions() []string {
+	versions := make([]string, 0, len(releases))
+	for version := range releases {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Image returns the image of a network function of the release, such as "amf",
+// hosted in the given registry, or in the default one when registry is empty
+func (r Release) Image(registry string, nf string) string {
+	if registry == "" {
+		registry = DefaultImageRegistry
+	}
+	return fmt.Sprintf("%s/%s:%s", registry, nf, r.ImageTag)
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+	"github.com/Kyuzial/free5gc-k8s/internal/release"
+)
+
+// Defaults of the typed configurations, matching the values rendered by the operator
+// when they are not set
+const (
+	defaultSBIScheme         = "http"
+	defaultHeartbeatInterval = "5s"
+	defaultRetransTimeout    = "1s"
+	defaultMaxRetrans        = 3
+)
+
+// defaultRequests are the resource requests of the components that do not set any
+// resources. Limits are left unset, so that bursts of signalling are not throttled.
+var defaultRequests = map[string]corev1.ResourceList{
+	"upf": {
+		corev1.ResourceCPU:    resource.MustParse("200m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	},
+	"control-plane": {
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	},
+}
+
+// defaultFree5GC sets the defaults of the spec. Images follow the free5gc version:
+// an image that is the default image of a release is replaced by the image of the
+// selected release. The typed configuration of a network function is not defaulted
+// when its configuration file is provided in config or configFrom, as the typed
+// configuration would override the file.
+func defaultFree5GC(free5gc *corev1alpha1.Free5GC) error {
+	spec := &free5gc.Spec
+	if spec.Version == "" {
+		spec.Version = release.DefaultVersion
+	}
+	r, err := release.Lookup(spec.Version)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range components(spec) {
+		c.spec.Image = defaultImage(c.spec.Image, spec.ImageRegistry, c.name, r)
+		defaultComponent(c.spec, defaultRequests["control-plane"])
+	}
+	if upf := spec.UPF; upf != nil {
+		upf.Image = defaultImage(upf.Image, spec.ImageRegistry, "upf", r)
+		defaultComponent(&upf.ComponentSpec, defaultRequests["upf"])
+		// Instances inherit the image of the UPF unless they set their own
+		if upf.ULCL != nil {
+			for i := range upf.ULCL.Instances {
+				defaultComponent(&upf.ULCL.Instances[i].ComponentSpec, defaultRequests["upf"])
+			}
+		}
+	}
+	if mongodb := spec.MongoDB; mongodb != nil && !mongodb.External {
+		if mongodb.Image == "" || isReleaseMongoDBImage(mongodb.Image) {
+			mongodb.Image = r.MongoDBImage
+		}
+		if mongodb.Storage != nil && mongodb.Storage.RetentionPolicy == "" {
+			mongodb.Storage.RetentionPolicy = corev1alpha1.RetentionPolicyDelete
+		}
+	}
+
+	defaultSBIConfigs(spec)
+	defaultPFCP(spec)
+	defaultNetwork(&spec.Network)
+	return nil
+}
+
+// defaultImage returns the image of the network function in the release, unless the
+// image is set to an image that is not the default image of a release
+func defaultImage(image, registry, nf string, r release.Release) string {
+	if image == "" || isReleaseImage(image, registry, nf) {
+		return r.Image(registry, nf)
+	}
+	return image
+}
+
+// isReleaseImage reports whether image is the image of the network function in a
+// release, hosted in the given registry or in the default one
+func isReleaseImage(image, registry, nf string) bool {
+	for _, version := range release.Versions() {
+		r, _ := release.Lookup(version)
+		if image == r.Image(registry, nf) || image == r.Image("", nf) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReleaseMongoDBImage reports whether image is the MongoDB image of a release
+func isReleaseMongoDBImage(image string) bool {
+	for _, version := range release.Versions() {
+		r, _ := release.Lookup(version)
+		if image == r.MongoDBImage {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultComponent sets the replicas and resource requests of a component
+func defaultComponent(spec *corev1alpha1.ComponentSpec, requests corev1.ResourceList) {
+	if spec.Replicas == nil {
+		spec.Replicas = ptr.To(int32(1))
+	}
+	if len(spec.Resources.Requests) == 0 && len(spec.Resources.Limits) == 0 && len(spec.Resources.Claims) == 0 {
+		spec.Resources.Requests = requests.DeepCopy()
+	}
+}
+
+// hasConfigFile reports whether the file is provided in the config or configFrom of a component
+func hasConfigFile(spec *corev1alpha1.ComponentSpec, name string) bool {
+	if _, ok := spec.Config[name]; ok {
+		return true
+	}
+	for _, source := range spec.ConfigFrom {
+		key := ""
+		if ref := source.ConfigMapKeyRef; ref != nil {
+			key = ref.Key
+		} else if ref := source.SecretKeyRef; ref != nil {
+			key = ref.Key
+		}
+		if source.Name == name || (source.Name == "" && key == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultSBI sets the scheme of an SBI configuration. The port is left unset, as a port
+// set in the configuration takes precedence over the sbi port of the network function:
+// the controller falls back to the sbi port, so that later changes of the port apply.
+func defaultSBI(sbi **corev1alpha1.SBIConfig) {
+	if *sbi == nil {
+		*sbi = &corev1alpha1.SBIConfig{}
+	}
+	if (*sbi).Scheme == "" {
+		(*sbi).Scheme = defaultSBIScheme
+	}
+}
+
+// defaultSBIConfigs sets the SBI scheme of the network functions
+func defaultSBIConfigs(spec *corev1alpha1.Free5GCSpec) {
+	if nrf := spec.NRF; nrf != nil && !hasConfigFile(&nrf.ComponentSpec, "nrfcfg.yaml") {
+		if nrf.NRFConfig == nil {
+			nrf.NRFConfig = &corev1alpha1.NRFConfig{}
+		}
+		defaultSBI(&nrf.NRFConfig.SBI)
+	}
+	if udr := spec.UDR; udr != nil && !hasConfigFile(&udr.ComponentSpec, "udrcfg.yaml") {
+		if udr.UDRConfig == nil {
+			udr.UDRConfig = &corev1alpha1.UDRConfig{}
+		}
+		defaultSBI(&udr.UDRConfig.SBI)
+	}
+	if udm := spec.UDM; udm != nil && !hasConfigFile(&udm.ComponentSpec, "udmcfg.yaml") {
+		if udm.UDMConfig == nil {
+			udm.UDMConfig = &corev1alpha1.UDMConfig{}
+		}
+		defaultSBI(&udm.UDMConfig.SBI)
+	}
+	if ausf := spec.AUSF; ausf != nil && !hasConfigFile(&ausf.ComponentSpec, "ausfcfg.yaml") {
+		if ausf.AUSFConfig == nil {
+			ausf.AUSFConfig = &corev1alpha1.AUSFConfig{}
+		}
+		defaultSBI(&ausf.AUSFConfig.SBI)
+	}
+	if pcf := spec.PCF; pcf != nil && !hasConfigFile(&pcf.ComponentSpec, "pcfcfg.yaml") {
+		if pcf.PCFConfig == nil {
+			pcf.PCFConfig = &corev1alpha1.PCFConfig{}
+		}
+		defaultSBI(&pcf.PCFConfig.SBI)
+	}
+	if nssf := spec.NSSF; nssf != nil && !hasConfigFile(&nssf.ComponentSpec, "nssfcfg.yaml") {
+		if nssf.NSSFConfig == nil {
+			nssf.NSSFConfig = &corev1alpha1.NSSFConfig{}
+		}
+		defaultSBI(&nssf.NSSFConfig.SBI)
+	}
+	if amf := spec.AMF; amf != nil && !hasConfigFile(&amf.ComponentSpec, "amfcfg.yaml") {
+		if amf.AMFConfig == nil {
+			amf.AMFConfig = &corev1alpha1.AMFConfig{}
+		}
+		defaultSBI(&amf.AMFConfig.SBI)
+	}
+	if smf := spec.SMF; smf != nil && !hasConfigFile(&smf.ComponentSpec, "smfcfg.yaml") {
+		if smf.SMFConfig == nil {
+			smf.SMFConfig = &corev1alpha1.SMFConfig{}
+		}
+		defaultSBI(&smf.SMFConfig.SBI)
+	}
+}
+
+// defaultPFCP sets the PFCP timers of the SMF and the UPF. The timers of the UPF are
+// inherited by the ULCL instances, so they are not defaulted when an instance provides
+// its own configuration file.
+func defaultPFCP(spec *corev1alpha1.Free5GCSpec) {
+	if smf := spec.SMF; smf != nil && !hasConfigFile(&smf.ComponentSpec, "smfcfg.yaml") {
+		if smf.SMFConfig == nil {
+			smf.SMFConfig = &corev1alpha1.SMFConfig{}
+		}
+		if smf.SMFConfig.PFCP == nil {
+			smf.SMFConfig.PFCP = &corev1alpha1.SMFPFCPConfig{}
+		}
+		if smf.SMFConfig.PFCP.HeartbeatInterval == "" {
+			smf.SMFConfig.PFCP.HeartbeatInterval = defaultHeartbeatInterval
+		}
+	}
+
+	upf := spec.UPF
+	if upf == nil || hasConfigFile(&upf.ComponentSpec, "upfcfg.yaml") {
+		return
+	}
+	if upf.ULCL != nil {
+		for i := range upf.ULCL.Instances {
+			if hasConfigFile(&upf.ULCL.Instances[i].ComponentSpec, "upfcfg.yaml") {
+				return
+			}
+		}
+	}
+	if upf.UPFConfig == nil {
+		upf.UPFConfig = &corev1alpha1.UPFConfig{}
+	}
+	if upf.UPFConfig.PFCP == nil {
+		upf.UPFConfig.PFCP = &corev1alpha1.PFCPConfig{}
+	}
+	if upf.UPFConfig.PFCP.RetransTimeout == "" {
+		upf.UPFConfig.PFCP.RetransTimeout = defaultRetransTimeout
+	}
+	if upf.UPFConfig.PFCP.MaxRetrans == 0 {
+		upf.UPFConfig.PFCP.MaxRetrans = defaultMaxRetrans
+	}
+}
+
+// defaultNetwork names the pod interface of each network attachment after its
+// reference point, such as n3
+func defaultNetwork(network *corev1alpha1.NetworkSpec) {
+	attachments := map[string]*corev1alpha1.NetworkAttachmentConfig{
+		"N2":  network.N2Network,
+		"N3":  network.N3Network,
+		"N4":  network.N4Network,
+		"N6":  network.N6Network,
+		"N9":  network.N9Network,
+		"NWu": network.NWuNetwork,
+	}
+	for name, config := range attachments {
+		if config != nil && config.Interface == "" {
+			config.Interface = strings.ToLower(name)
+		}
+	}
+}
@@ -0,0 +1,534 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var free5gclog = logf.Log.WithName("free5gc-resource")
+
+// cniModes are the modes supported by the CNI plugins of the network attachments
+var cniModes = map[string][]string{
+	"macvlan": {"bridge", "private", "vepa", "passthru"},
+	"ipvlan":  {"l2", "l3", "l3s"},
+}
+
+// sdPattern matches a Slice Differentiator made of 6 hexadecimal digits
+var sdPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// SetupFree5GCWebhookWithManager registers the webhook for Free5GC in the manager.
+func SetupFree5GCWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1alpha1.Free5GC{}).
+		WithValidator(&Free5GCCustomValidator{}).
+		WithDefaulter(&Free5GCCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-free5gc-org-v1alpha1-free5gc,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.free5gc.org,resources=free5gcs,verbs=create;update,versions=v1alpha1,name=mfree5gc-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// Free5GCCustomDefaulter sets the defaults of the Free5GC resources when they are created
+// or updated, so that the stored object shows the effective configuration.
+type Free5GCCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &Free5GCCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Free5GC.
+func (d *Free5GCCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	free5gc, ok := obj.(*corev1alpha1.Free5GC)
+	if !ok {
+		return fmt.Errorf("expected a Free5GC object but got %T", obj)
+	}
+	free5gclog.Info("Defaulting for Free5GC", "name", free5gc.GetName())
+
+	return defaultFree5GC(free5gc)
+}
+
+// +kubebuilder:webhook:path=/validate-core-free5gc-org-v1alpha1-free5gc,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.free5gc.org,resources=free5gcs,verbs=create;update,versions=v1alpha1,name=vfree5gc-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// Free5GCCustomValidator validates the Free5GC resources when they are created or updated,
+// so that invalid specs are rejected instead of being discovered as crashing pods.
+type Free5GCCustomValidator struct{}
+
+var _ webhook.CustomValidator = &Free5GCCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Free5GC.
+func (v *Free5GCCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	free5gc, ok := obj.(*corev1alpha1.Free5GC)
+	if !ok {
+		return nil, fmt.Errorf("expected a Free5GC object but got %T", obj)
+	}
+	free5gclog.Info("Validation for Free5GC upon creation", "name", free5gc.GetName())
+
+	return nil, validateFree5GC(free5gc)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Free5GC.
+func (v *Free5GCCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	free5gc, ok := newObj.(*corev1alpha1.Free5GC)
+	if !ok {
+		return nil, fmt.Errorf("expected a Free5GC object for the newObj but got %T", newObj)
+	}
+	free5gclog.Info("Validation for Free5GC upon update", "name", free5gc.GetName())
+
+	return nil, validateFree5GC(free5gc)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Free5GC.
+func (v *Free5GCCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateFree5GC returns an Invalid error listing every problem of the spec, or nil
+func validateFree5GC(free5gc *corev1alpha1.Free5GC) error {
+	specPath := field.NewPath("spec")
+	spec := &free5gc.Spec
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateNetwork(&spec.Network, specPath.Child("network"))...)
+	allErrs = append(allErrs, validateMongoDB(spec.MongoDB, specPath.Child("mongodb"))...)
+	allErrs = append(allErrs, validateSlices(spec, specPath)...)
+	allErrs = append(allErrs, validateComponents(spec, specPath)...)
+	allErrs = append(allErrs, validateULCL(spec.UPF, specPath.Child("upf"))...)
+	allErrs = append(allErrs, validateUDM(spec.UDM, specPath.Child("udm"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(corev1alpha1.GroupVersion.WithKind("Free5GC").GroupKind(), free5gc.Name, allErrs)
+}
+
+// validateNetwork checks the addresses of the network attachments
+func validateNetwork(network *corev1alpha1.NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	attachments := []struct {
+		name   string
+		config *corev1alpha1.NetworkAttachmentConfig
+	}{
+		{"n2Network", network.N2Network},
+		{"n3Network", network.N3Network},
+		{"n4Network", network.N4Network},
+		{"n6Network", network.N6Network},
+		{"n9Network", network.N9Network},
+		{"nwuNetwork", network.NWuNetwork},
+	}
+	for _, attachment := range attachments {
+		if attachment.config != nil {
+			allErrs = append(allErrs, validateNetworkAttachment(attachment.config, fldPath.Child(attachment.name))...)
+		}
+	}
+	for i, family := range network.IPFamilies {
+		if slices.Index(network.IPFamilies, family) < i {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("ipFamilies").Index(i), family))
+		}
+	}
+	return allErrs
+}
+
+// validateCIDR checks that a CIDR is valid and, unless family is empty, of that IP family
+func validateCIDR(cidr string, family corev1.IPFamily, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	ip, _, err := net.ParseCIDR(cidr)
+	switch {
+	case err != nil:
+		allErrs = append(allErrs, field.Invalid(fldPath, cidr, "must be a valid CIDR"))
+	case family == corev1.IPv4Protocol && ip.To4() == nil,
+		family == corev1.IPv6Protocol && ip.To4() != nil:
+		allErrs = append(allErrs, field.Invalid(fldPath, cidr, fmt.Sprintf("must be an %s CIDR", family)))
+	}
+	return allErrs
+}
+
+// validateNetworkAttachment checks that the addresses of a network attachment are valid
+// and that the gateway and static IP belong to its subnet
+func validateNetworkAttachment(config *corev1alpha1.NetworkAttachmentConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var subnet *net.IPNet
+	if config.CIDR != "" {
+		_, ipNet, err := net.ParseCIDR(config.CIDR)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), config.CIDR, "must be a valid CIDR"))
+		} else {
+			subnet = ipNet
+		}
+	}
+
+	inSubnet := func(name, value string) {
+		if value == "" {
+			return
+		}
+		ip := net.ParseIP(value)
+		switch {
+		case ip == nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, "must be a valid IP address"))
+		case subnet != nil && !subnet.Contains(ip):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), value, fmt.Sprintf("must be in the subnet %s", config.CIDR)))
+		}
+	}
+	inSubnet("gateway", config.Gateway)
+	inSubnet("staticIP", config.StaticIP)
+
+	if config.Mode != "" {
+		modes, ok := cniModes[config.Type]
+		switch {
+		case !ok:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mode"), config.Mode, "can only be set along with type"))
+		case !slices.Contains(modes, config.Mode):
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), config.Mode, modes))
+		}
+	}
+
+	if config.MAC != "" {
+		if _, err := net.ParseMAC(config.MAC); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mac"), config.MAC, "must be a valid MAC address"))
+		} else if config.Type == "ipvlan" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mac"), config.MAC, "cannot be set on ipvlan networks"))
+		}
+	}
+
+	if config.ExcludeIP != "" && net.ParseIP(config.ExcludeIP) == nil {
+		if _, _, err := net.ParseCIDR(config.ExcludeIP); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("excludeIP"), config.ExcludeIP, "must be a valid IP address or CIDR"))
+		}
+	}
+	return allErrs
+}
+
+// validateMongoDB checks the MongoDB deployment or connection settings
+func validateMongoDB(mongodb *corev1alpha1.MongoDBSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if mongodb == nil {
+		return allErrs
+	}
+	if mongodb.External && mongodb.URI == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("uri"), "must be set when using an external MongoDB"))
+	}
+	if storage := mongodb.Storage; storage != nil {
+		if _, err := resource.ParseQuantity(storage.Size); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("storage", "size"), storage.Size, err.Error()))
+		}
+	}
+	if mongodb.Probes != nil {
+		allErrs = append(allErrs, validateProbes("mongodb", mongodb.Probes, fldPath.Child("probes"))...)
+	}
+	return allErrs
+}
+
+// validateSlices checks the S-NSSAIs of the slices and of the typed configurations
+func validateSlices(spec *corev1alpha1.Free5GCSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, slice := range spec.Slices {
+		slicePath := fldPath.Child("slices").Index(i)
+		allErrs = append(allErrs, validateSNSSAI(slice.SNSSAIConfig, slicePath)...)
+		for j, dnn := range slice.DNNs {
+			dnnPath := slicePath.Child("dnns").Index(j)
+			if dnn.CIDR != "" {
+				allErrs = append(allErrs, validateCIDR(dnn.CIDR, corev1.IPv4Protocol, dnnPath.Child("cidr"))...)
+			}
+			if dnn.IPv6CIDR != "" {
+				allErrs = append(allErrs, validateCIDR(dnn.IPv6CIDR, corev1.IPv6Protocol, dnnPath.Child("ipv6Cidr"))...)
+			}
+		}
+	}
+
+	if amf := spec.AMF; amf != nil && amf.AMFConfig != nil {
+		for i, support := range amf.AMFConfig.PLMNSupportList {
+			for j, snssai := range support.SNSSAIList {
+				allErrs = append(allErrs, validateSNSSAI(snssai,
+					fldPath.Child("amf", "amfConfig", "plmnSupportList").Index(i).Child("snssaiList").Index(j))...)
+			}
+		}
+	}
+	if smf := spec.SMF; smf != nil && smf.SMFConfig != nil {
+		for i, info := range smf.SMFConfig.SNSSAIInfos {
+			allErrs = append(allErrs, validateSNSSAI(info.SNSSAI,
+				fldPath.Child("smf", "smfConfig", "snssaiInfos").Index(i).Child("sNssai"))...)
+		}
+		for i, pool := range smf.SMFConfig.UEIPPools {
+			for j, cidr := range pool.Pools {
+				allErrs = append(allErrs, validateCIDR(cidr, "",
+					fldPath.Child("smf", "smfConfig", "ueIpPools").Index(i).Child("pools").Index(j))...)
+			}
+		}
+	}
+	if nssf := spec.NSSF; nssf != nil && nssf.NSSFConfig != nil {
+		for i, nsi := range nssf.NSSFConfig.NSIList {
+			if nsi.SNSSAI != nil {
+				allErrs = append(allErrs, validateSNSSAI(*nsi.SNSSAI,
+					fldPath.Child("nssf", "nssfConfig", "nsiList").Index(i).Child("snssai"))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateSNSSAI checks that the SST is in range and that the SD is made of 6 hexadecimal digits
+func validateSNSSAI(snssai corev1alpha1.SNSSAIConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if snssai.SST < 1 || snssai.SST > 255 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sst"), snssai.SST, "must be between 1 and 255"))
+	}
+	if snssai.SD != "" && !sdPattern.MatchString(snssai.SD) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sd"), snssai.SD, "must be made of 6 hexadecimal digits"))
+	}
+	return allErrs
+}
+
+// component is a component whose deployment settings are validated
+type component struct {
+	name string
+	spec *corev1alpha1.ComponentSpec
+	// singleton is true for network functions that hold node-level state (SCTP
+	// associations, PFCP associations, GTP-U tunnels or IKE SAs), which cannot
+	// be shared between replicas
+	singleton bool
+}
+
+// components returns the components of the spec with a deployment, except the UPF
+// and its ULCL instances
+func components(spec *corev1alpha1.Free5GCSpec) []component {
+	var components []component
+	if spec.NRF != nil {
+		components = append(components, component{"nrf", &spec.NRF.ComponentSpec, false})
+	}
+	if spec.UDR != nil {
+		components = append(components, component{"udr", &spec.UDR.ComponentSpec, false})
+	}
+	if spec.UDM != nil {
+		components = append(components, component{"udm", &spec.UDM.ComponentSpec, false})
+	}
+	if spec.AUSF != nil {
+		components = append(components, component{"ausf", &spec.AUSF.ComponentSpec, false})
+	}
+	if spec.PCF != nil {
+		components = append(components, component{"pcf", &spec.PCF.ComponentSpec, false})
+	}
+	if spec.NSSF != nil {
+		components = append(components, component{"nssf", &spec.NSSF.ComponentSpec, false})
+	}
+	if spec.AMF != nil {
+		components = append(components, component{"amf", &spec.AMF.ComponentSpec, true})
+	}
+	if spec.SMF != nil {
+		components = append(components, component{"smf", &spec.SMF.ComponentSpec, true})
+	}
+	if spec.N3IWF != nil {
+		components = append(components, component{"n3iwf", spec.N3IWF, true})
+	}
+	if spec.WebUI != nil {
+		components = append(components, component{"webui", spec.WebUI, false})
+	}
+	return components
+}
+
+// validateComponents checks the deployment settings shared by every component
+func validateComponents(spec *corev1alpha1.Free5GCSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, c := range components(spec) {
+		allErrs = append(allErrs, validateComponent(spec, c.name, c.spec, c.singleton, fldPath.Child(c.name))...)
+	}
+	if upf := spec.UPF; upf != nil {
+		allErrs = append(allErrs, validateComponent(spec, "upf", &upf.ComponentSpec, true, fldPath.Child("upf"))...)
+		if upf.ULCL != nil {
+			for i := range upf.ULCL.Instances {
+				allErrs = append(allErrs, validateComponent(spec, "upf", &upf.ULCL.Instances[i].ComponentSpec, true,
+					fldPath.Child("upf", "ulcl", "instances").Index(i))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// componentPortNames are the names of the ports each component listens on
+var componentPortNames = map[string][]string{
+	"nrf":   {"sbi"},
+	"udr":   {"sbi"},
+	"udm":   {"sbi"},
+	"ausf":  {"sbi"},
+	"pcf":   {"sbi"},
+	"nssf":  {"sbi"},
+	"amf":   {"sbi", "ngap"},
+	"smf":   {"sbi", "pfcp"},
+	"upf":   {"pfcp", "gtpu"},
+	"n3iwf": {"ike", "nat-t"},
+	"webui": {"http"},
+}
+
+// validateComponent checks the replicas, networks, ports, exposure, probes and configuration sources of a component
+func validateComponent(free5gcSpec *corev1alpha1.Free5GCSpec, name string, spec *corev1alpha1.ComponentSpec, singleton bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if replicas := spec.Replicas; replicas != nil {
+		switch {
+		case *replicas < 0:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *replicas, "must not be negative"))
+		case singleton && *replicas > 1:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *replicas, "cannot be scaled beyond one replica"))
+		}
+	}
+	networks := map[string]*corev1alpha1.NetworkAttachmentConfig{
+		"N2":  free5gcSpec.Network.N2Network,
+		"N3":  free5gcSpec.Network.N3Network,
+		"N4":  free5gcSpec.Network.N4Network,
+		"N6":  free5gcSpec.Network.N6Network,
+		"N9":  free5gcSpec.Network.N9Network,
+		"NWu": free5gcSpec.Network.NWuNetwork,
+	}
+	seen := map[string]bool{}
+	for i, refPoint := range spec.Networks {
+		networkPath := fldPath.Child("networks").Index(i)
+		switch {
+		case seen[refPoint]:
+			allErrs = append(allErrs, field.Duplicate(networkPath, refPoint))
+		case networks[refPoint] == nil:
+			allErrs = append(allErrs, field.Invalid(networkPath, refPoint, "must be a reference point with a network set in spec.network"))
+		case networks[refPoint].CIDR != "" && spec.Replicas != nil && *spec.Replicas > 1:
+			allErrs = append(allErrs, field.Invalid(networkPath, refPoint,
+				"cannot be attached to several replicas, as the addresses allocated from the network CIDR are assigned to a single pod"))
+		}
+		seen[refPoint] = true
+	}
+	for i, port := range spec.Ports {
+		if !slices.Contains(componentPortNames[name], port.Name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("ports").Index(i).Child("name"),
+				port.Name, componentPortNames[name]))
+		}
+	}
+	if spec.Expose != nil {
+		allErrs = append(allErrs, validateExpose(name, spec.Expose, fldPath.Child("expose"))...)
+	}
+	if spec.Probes != nil {
+		allErrs = append(allErrs, validateProbes(name, spec.Probes, fldPath.Child("probes"))...)
+	}
+	for i, source := range spec.ConfigFrom {
+		if (source.ConfigMapKeyRef == nil) == (source.SecretKeyRef == nil) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("configFrom").Index(i), source.Name,
+				"must set exactly one of configMapKeyRef and secretKeyRef"))
+		}
+	}
+	return allErrs
+}
+
+// validateExpose checks that the load balancer settings match the Service type, and that
+// only the WebUI is exposed through an Ingress
+func validateExpose(name string, expose *corev1alpha1.ExposeSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if expose.LoadBalancerIP != "" {
+		switch {
+		case expose.Type != corev1.ServiceTypeLoadBalancer:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("loadBalancerIP"), expose.LoadBalancerIP,
+				"requires the LoadBalancer type"))
+		case net.ParseIP(expose.LoadBalancerIP) == nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("loadBalancerIP"), expose.LoadBalancerIP,
+				"must be an IP address"))
+		}
+	}
+	if expose.ExternalTrafficPolicy != "" && expose.Type != corev1.ServiceTypeNodePort &&
+		expose.Type != corev1.ServiceTypeLoadBalancer {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("externalTrafficPolicy"), expose.ExternalTrafficPolicy,
+			"requires the NodePort or LoadBalancer type"))
+	}
+	if expose.Ingress != nil && name != "webui" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingress"), "only the WebUI can be exposed through an Ingress"))
+	}
+	return allErrs
+}
+
+// validateProbes checks that the liveness and startup probes succeed after a single
+// success, as Kubernetes requires, and that only the components serving an SBI are
+// probed on an SBI path
+func validateProbes(name string, probes *corev1alpha1.ProbesSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, probe := range []struct {
+		name string
+		spec *corev1alpha1.ProbeSpec
+	}{
+		{"liveness", probes.Liveness},
+		{"startup", probes.Startup},
+	} {
+		if probe.spec != nil && probe.spec.SuccessThreshold != nil && *probe.spec.SuccessThreshold != 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(probe.name, "successThreshold"),
+				*probe.spec.SuccessThreshold, "must be 1"))
+		}
+	}
+	if probes.SBIPath != "" && !slices.Contains(componentPortNames[name], "sbi") {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("sbiPath"), "only the network functions serving an SBI can be probed on an SBI path"))
+	}
+	return allErrs
+}
+
+// validateULCL checks that the ULCL instance names are unique DNS labels, as they name
+// the resources and user plane nodes of the instances
+func validateULCL(upf *corev1alpha1.UPFSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if upf == nil || upf.ULCL == nil {
+		return allErrs
+	}
+	seen := map[string]bool{}
+	for i, instance := range upf.ULCL.Instances {
+		namePath := fldPath.Child("ulcl", "instances").Index(i).Child("name")
+		if instance.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, "must be set"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Label(instance.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, instance.Name, msg))
+		}
+		if seen[instance.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, instance.Name))
+		}
+		seen[instance.Name] = true
+	}
+	return allErrs
+}
+
+// validateUDM checks that the SUCI profiles select the Secret keys of their private keys
+func validateUDM(udm *corev1alpha1.UDMSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if udm == nil || udm.UDMConfig == nil {
+		return allErrs
+	}
+	for i, profile := range udm.UDMConfig.SUCIProfiles {
+		refPath := fldPath.Child("udmConfig", "suciProfiles").Index(i).Child("privateKeySecretRef")
+		if profile.PrivateKeySecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "the Secret of the private key must be set"))
+		}
+		if profile.PrivateKeySecretRef.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), "the key of the private key must be set"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	corev1alpha1 "github.com/Kyuzial/free5gc-k8s/api/v1alpha1"
+	corev1beta1 "github.com/Kyuzial/free5gc-k8s/api/v1beta1"
+)
+
+var _ = Describe("Free5GC Webhook", func() {
+	var (
+		obj       *corev1alpha1.Free5GC
+		validator Free5GCCustomValidator
+		defaulter Free5GCCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &corev1alpha1.Free5GC{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resource",
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.Free5GCSpec{
+				MongoDB: &corev1alpha1.MongoDBSpec{
+					Storage: &corev1alpha1.StorageSpec{Size: "1Gi"},
+				},
+				Network: corev1alpha1.NetworkSpec{
+					N3Network: &corev1alpha1.NetworkAttachmentConfig{
+						Name:      "n3-net",
+						Interface: "n3",
+						CIDR:      "10.100.50.0/24",
+						Gateway:   "10.100.50.1",
+						StaticIP:  "10.100.50.233",
+					},
+				},
+				Slices: []corev1alpha1.SliceSpec{{SNSSAIConfig: corev1alpha1.SNSSAIConfig{SST: 1, SD: "010203"}}},
+				AMF:    &corev1alpha1.AMFSpec{ComponentSpec: corev1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))}},
+				NRF:    &corev1alpha1.NRFSpec{ComponentSpec: corev1alpha1.ComponentSpec{Replicas: ptr.To(int32(3))}},
+				UPF: &corev1alpha1.UPFSpec{ULCL: &corev1alpha1.ULCLSpec{
+					Enabled:   true,
+					Instances: []corev1alpha1.UPFInstance{{Name: "upf1"}, {Name: "upf2"}},
+				}},
+			},
+		}
+	})
+
+	Context("When creating Free5GC under Defaulting Webhook", func() {
+		It("Should set the version and the images of the version", func() {
+			obj.Spec.PCF = &corev1alpha1.PCFSpec{}
+			Expect(defaulter.Default(context.Background(), obj)).To(Succeed())
+			Expect(obj.Spec.Version).To(Equal("v3.3.0"))
+			Expect(obj.Spec.AMF.Image).To(Equal("free5gc/amf:v3.3.0"))
+			Expect(obj.Spec.PCF.Image).To(Equal("free5gc/pcf:v3.3.0"))
+			Expect(obj.Spec.UPF.Image).To(Equal("free5gc/upf:v3.3.0"))
+			Expect(obj.Spec.UPF.ULCL.Instances[0].Image).To(BeEmpty())
+			Expect(obj.Spec.MongoDB.Image).To(Equal("mongo:4.4"))
+		})
+
+		It("Should make the default images follow the version", func() {
+			obj.Spec.Version = "v3.4.0"
+			obj.Spec.AMF.Image = "free5gc/amf:v3.3.0"
+			obj.Spec.NRF.Image = "registry.example.com/nrf:custom"
+			Expect(defaulter.Default(context.Background(), obj)).To(Succeed())
+			Expect(obj.Spec.AMF.Image).To(Equal("free5gc/amf:v3.4.0"))
+			Expect(obj.Spec.NRF.Image).To(Equal("registry.example.com/nrf:custom"))
+		})
+
+		It("Should set the replicas and resource requests", func() {
+			obj.Spec.AMF.Replicas = nil
+			Expect(defaulter.Default(context.Background(), obj)).To(Succeed())
+			Expect(obj.Spec.AMF.Replicas).To(HaveValue(BeNumerically("==", 1)))
+			Expect(obj.Spec.NRF.Replicas).To(HaveValue(BeNumerically("==", 3)))
+			Expect(obj.Spec.AMF.Resources.Requests.Cpu().String()).To(Equal("100m"))
+			Expect(obj.Spec.UPF.ULCL.Instances[1].Resources.Requests.Memory().String()).To(Equal("256Mi"))
+		})
+
+		It("Should set the SBI, PFCP and interface defaults", func() {
+			obj.Spec.SMF = &corev1alpha1.SMFSpec{}
+			Expect(defaulter.Default(context.Background(), obj)).To(Succeed())
+			Expect(obj.Spec.AMF.AMFConfig.SBI.Scheme).To(Equal("http"))
+			Expect(obj.Spec.SMF.SMFConfig.PFCP.HeartbeatInterval).To(Equal("5s"))
+			Expect(obj.Spec.UPF.UPFConfig.PFCP.RetransTimeout).To(Equal("1s"))
+			Expect(obj.Spec.UPF.UPFConfig.PFCP.MaxRetrans).To(BeNumerically("==", 3))
+			Expect(obj.Spec.Network.N3Network.Interface).To(Equal("n3"))
+			Expect(obj.Spec.MongoDB.Storage.RetentionPolicy).To(Equal(corev1alpha1.RetentionPolicyDelete))
+		})
+
+		It("Should leave the SBI port to follow the sbi port", func() {
+			obj.Spec.NRF.Ports = []corev1alpha1.PortSpec{{Name: "sbi", Port: 29510}}
+			Expect(defaulter.Default(context.Background(), obj)).To(Succeed())
+			Expect(obj.Spec.NRF.NRFConfig.SBI.Port).To(BeZero())
+			Expect(obj.Spec.AMF.AMFConfig.SBI.Port).To(BeZero())
+		})
+
+		It("Should not default the typed configuration overriding a provided file", func() {
+			obj.Spec.AMF.Config = map[string]string{"amfcfg.yaml": "configuration: {}\n"}
+			obj.Spec.UPF.ULCL.Instances[0].ConfigFrom = []corev1alpha1.ConfigFileSource{{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "upf1"},
+					Key:                  "upfcfg.yaml",
+				},
+			}}
+			Expect(defaulter.Default(context.Background(), obj)).To(Succeed())
+			Expect(obj.Spec.AMF.AMFConfig).To(BeNil())
+			Expect(obj.Spec.UPF.UPFConfig).To(BeNil())
+			Expect(obj.Spec.NRF.NRFConfig.SBI).NotTo(BeNil())
+		})
+	})
+
+	Context("When creating or updating Free5GC under Validating Webhook", func() {
+		It("Should admit a valid spec", func() {
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(context.Background(), obj.DeepCopy(), obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny invalid network attachment addresses", func() {
+			obj.Spec.Network.N3Network.CIDR = "10.100.50.0/33"
+			obj.Spec.Network.N3Network.Gateway = "10.100.50"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.network.n3Network.cidr")))
+			Expect(err).To(MatchError(ContainSubstring("spec.network.n3Network.gateway")))
+		})
+
+		It("Should deny a CNI mode not supported by the CNI plugin", func() {
+			obj.Spec.Network.N3Network.Type = "ipvlan"
+			obj.Spec.Network.N3Network.Mode = "bridge"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.network.n3Network.mode: Unsupported value")))
+		})
+
+		It("Should deny a MAC address on an ipvlan network", func() {
+			obj.Spec.Network.N3Network.Type = "ipvlan"
+			obj.Spec.Network.N3Network.MAC = "02:00:00:00:00:01"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.network.n3Network.mac")))
+		})
+
+		It("Should deny a static IP outside its subnet", func() {
+			obj.Spec.Network.N3Network.StaticIP = "10.100.51.233"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("must be in the subnet 10.100.50.0/24")))
+		})
+
+		It("Should deny attaching networks that are not set", func() {
+			obj.Spec.AMF.Networks = []string{"N3", "N2"}
+			obj.Spec.UPF.ULCL.Instances[0].Networks = []string{"N3", "N3"}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.amf.networks[1]: Invalid value: \"N2\"")))
+			Expect(err).To(MatchError(ContainSubstring("spec.upf.ulcl.instances[0].networks[1]: Duplicate value")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.amf.networks[0]")))
+		})
+
+		It("Should deny attaching networks with a CIDR to several replicas", func() {
+			obj.Spec.NRF.Networks = []string{"N3"}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.nrf.networks[0]: Invalid value: \"N3\": cannot be attached to several replicas")))
+		})
+
+		It("Should accept IPv6 networks and UE pools", func() {
+			obj.Spec.Network.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+			obj.Spec.Network.N3Network.CIDR = "2001:db8:50::/64"
+			obj.Spec.Network.N3Network.Gateway = "2001:db8:50::1"
+			obj.Spec.Network.N3Network.StaticIP = "2001:db8:50::233"
+			obj.Spec.Slices[0].DNNs = []corev1alpha1.SliceDNNSpec{{DNN: "internet", IPv6CIDR: "2001:db8:60::/48"}}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny UE pools of the wrong IP family", func() {
+			obj.Spec.Network.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv4Protocol}
+			obj.Spec.Slices[0].DNNs = []corev1alpha1.SliceDNNSpec{{DNN: "internet", CIDR: "2001:db8:60::/48", IPv6CIDR: "10.60.0.0/16"}}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.slices[0].dnns[0].cidr: Invalid value: \"2001:db8:60::/48\": must be an IPv4 CIDR")))
+			Expect(err).To(MatchError(ContainSubstring("spec.slices[0].dnns[0].ipv6Cidr: Invalid value: \"10.60.0.0/16\": must be an IPv6 CIDR")))
+			Expect(err).To(MatchError(ContainSubstring("spec.network.ipFamilies[1]: Duplicate value")))
+		})
+
+		It("Should deny duplicate ULCL instance names", func() {
+			obj.Spec.UPF.ULCL.Instances[1].Name = "upf1"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.upf.ulcl.instances[1].name: Duplicate value")))
+		})
+
+		It("Should deny ULCL instance names that are not DNS labels", func() {
+			obj.Spec.UPF.ULCL.Instances[0].Name = ""
+			obj.Spec.UPF.ULCL.Instances[1].Name = "UPF_2"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.upf.ulcl.instances[0].name: Required value")))
+			Expect(err).To(MatchError(ContainSubstring("spec.upf.ulcl.instances[1].name: Invalid value: \"UPF_2\"")))
+		})
+
+		It("Should deny an invalid MongoDB storage size", func() {
+			obj.Spec.MongoDB.Storage.Size = "1 GB"
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.mongodb.storage.size")))
+		})
+
+		It("Should deny an external MongoDB without URI", func() {
+			obj.Spec.MongoDB.External = true
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.mongodb.uri: Required value")))
+		})
+
+		It("Should deny malformed S-NSSAIs", func() {
+			obj.Spec.Slices[0].SST = 256
+			obj.Spec.Slices[0].SD = "01020g"
+			obj.Spec.AMF.AMFConfig = &corev1alpha1.AMFConfig{PLMNSupportList: []corev1alpha1.PLMNSupportConfig{{
+				SNSSAIList: []corev1alpha1.SNSSAIConfig{{SST: 1, SD: "0102"}, {SD: "010203"}},
+			}}}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.slices[0].sst")))
+			Expect(err).To(MatchError(ContainSubstring("spec.slices[0].sd")))
+			Expect(err).To(MatchError(ContainSubstring("spec.amf.amfConfig.plmnSupportList[0].snssaiList[0].sd")))
+			Expect(err).To(MatchError(ContainSubstring("spec.amf.amfConfig.plmnSupportList[0].snssaiList[1].sst")))
+		})
+
+		It("Should deny SUCI profiles without the Secret key of their private key", func() {
+			obj.Spec.UDM = &corev1alpha1.UDMSpec{UDMConfig: &corev1alpha1.UDMConfig{SUCIProfiles: []corev1alpha1.SUCIProfileConfig{{
+				ProtectionScheme: 1,
+				PrivateKeySecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "suci-keys"},
+				},
+			}}}}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.udm.udmConfig.suciProfiles[0].privateKeySecretRef.key: Required value")))
+		})
+
+		It("Should deny scaling network functions that cannot scale", func() {
+			obj.Spec.AMF.Replicas = ptr.To(int32(2))
+			obj.Spec.UPF.ULCL.Instances[0].Replicas = ptr.To(int32(2))
+			_, err := validator.ValidateUpdate(context.Background(), obj.DeepCopy(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.amf.replicas")))
+			Expect(err).To(MatchError(ContainSubstring("spec.upf.ulcl.instances[0].replicas")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.nrf.replicas")))
+		})
+
+		It("Should deny exposure settings that do not match the Service type", func() {
+			obj.Spec.AMF.Expose = &corev1alpha1.ExposeSpec{
+				Type:                  corev1.ServiceTypeNodePort,
+				LoadBalancerIP:        "192.0.2.10",
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			}
+			obj.Spec.NRF.Expose = &corev1alpha1.ExposeSpec{
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+				Ingress:               &corev1alpha1.IngressSpec{Host: "nrf.example.com"},
+			}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.amf.expose.loadBalancerIP: Invalid value: \"192.0.2.10\": requires the LoadBalancer type")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.amf.expose.externalTrafficPolicy")))
+			Expect(err).To(MatchError(ContainSubstring("spec.nrf.expose.externalTrafficPolicy")))
+			Expect(err).To(MatchError(ContainSubstring("spec.nrf.expose.ingress: Forbidden")))
+		})
+
+		It("Should deny probes Kubernetes or the component cannot run", func() {
+			obj.Spec.AMF.Probes = &corev1alpha1.ProbesSpec{
+				SBIPath:   "/namf-comm/v1",
+				Readiness: &corev1alpha1.ProbeSpec{SuccessThreshold: ptr.To(int32(2))},
+				Liveness:  &corev1alpha1.ProbeSpec{SuccessThreshold: ptr.To(int32(2))},
+			}
+			obj.Spec.UPF.Probes = &corev1alpha1.ProbesSpec{SBIPath: "/"}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.amf.probes.liveness.successThreshold: Invalid value: 2: must be 1")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.amf.probes.readiness")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.amf.probes.sbiPath")))
+			Expect(err).To(MatchError(ContainSubstring("spec.upf.probes.sbiPath: Forbidden")))
+		})
+
+		It("Should deny ports the component does not listen on", func() {
+			obj.Spec.AMF.Ports = []corev1alpha1.PortSpec{{Name: "ngap", Port: 38413}}
+			obj.Spec.NRF.Ports = []corev1alpha1.PortSpec{{Name: "ngap", Port: 38413}}
+			_, err := validator.ValidateCreate(context.Background(), obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.nrf.ports[0].name: Unsupported value: \"ngap\"")))
+			Expect(err).NotTo(MatchError(ContainSubstring("spec.amf.ports")))
+		})
+	})
+
+	Context("When converting Free5GC under Conversion Webhook", func() {
+		It("Should move the ULCL instances into the topology", func() {
+			obj.Spec.UPF.Image = "registry.example.com/upf:custom"
+			obj.Spec.UPF.ULCL.Instances[1].UPFConfig = &corev1alpha1.UPFConfig{DNNList: []corev1alpha1.UPFDNNConfig{{DNN: "internet", CIDR: "10.60.0.0/16"}}}
+			obj.Spec.AMF.Config = map[string]string{"amfcfg.yaml": "configuration: {}\n"}
+			obj.Spec.AMF.AMFConfig = &corev1alpha1.AMFConfig{AMFName: "AMF"}
+			obj.Spec.N3IWF = &corev1alpha1.ComponentSpec{Replicas: ptr.To(int32(1))}
+
+			spoke := &corev1beta1.Free5GC{}
+			Expect(spoke.ConvertFrom(obj)).To(Succeed())
+			Expect(spoke.Name).To(Equal("test-resource"))
+			nfs := spoke.Spec.NetworkFunctions
+			Expect(nfs.UPF.Image).To(Equal("registry.example.com/upf:custom"))
+			Expect(nfs.AMF.ConfigFiles).To(HaveKey("amfcfg.yaml"))
+			Expect(nfs.AMF.Config.AMFName).To(Equal("AMF"))
+			Expect(nfs.N3IWF.Replicas).To(HaveValue(BeNumerically("==", 1)))
+			Expect(spoke.Spec.Topology.ULCL.Enabled).To(BeTrue())
+			Expect(spoke.Spec.Topology.ULCL.Instances).To(HaveLen(2))
+			Expect(spoke.Spec.Topology.ULCL.Instances[1].Config.DNNList).To(HaveLen(1))
+
+			hub := &corev1alpha1.Free5GC{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub).To(Equal(obj))
+		})
+
+		It("Should hold a topology without UPF in an empty UPF", func() {
+			spoke := &corev1beta1.Free5GC{Spec: corev1beta1.Free5GCSpec{
+				Topology: &corev1beta1.TopologySpec{ULCL: &corev1beta1.ULCLSpec{
+					Enabled:   true,
+					Instances: []corev1beta1.UPFInstanceSpec{{Name: "upf1"}},
+				}},
+			}}
+			hub := &corev1alpha1.Free5GC{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Spec.UPF.ULCL.Instances).To(HaveLen(1))
+			Expect(hub.Spec.UPF.ComponentSpec).To(Equal(corev1alpha1.ComponentSpec{}))
+
+			roundTrip := &corev1beta1.Free5GC{}
+			Expect(roundTrip.ConvertFrom(hub)).To(Succeed())
+			Expect(roundTrip).To(Equal(spoke))
+		})
+
+		It("Should not create a UPF for a disabled topology", func() {
+			spoke := &corev1beta1.Free5GC{Spec: corev1beta1.Free5GCSpec{
+				Topology: &corev1beta1.TopologySpec{ULCL: &corev1beta1.ULCLSpec{
+					Instances: []corev1beta1.UPFInstanceSpec{{Name: "upf1"}},
+				}},
+			}}
+			hub := &corev1alpha1.Free5GC{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+			Expect(hub.Spec.UPF).To(BeNil())
+		})
+	})
+})